@@ -27,6 +27,8 @@ const (
 	ClusterConditionReady = "Ready"
 	// CapiClusterFinalizer is the finalizer applied to capi clusters.
 	CapiClusterFinalizer = "capicluster.turtles.cattle.io"
+	// AgentCleanupFinalizer is the finalizer holding the deletion of a Rancher cluster until its agent is removed.
+	AgentCleanupFinalizer = "agentcleanup.turtles.cattle.io"
 )
 
 // Cluster is the struct representing a Rancher Cluster.
@@ -40,6 +40,7 @@ type ClusterRegistrationTokenSpec struct {
 // ClusterRegistrationTokenStatus is the struct representing the status of a Rancher ClusterRegistrationToken.
 type ClusterRegistrationTokenStatus struct {
 	ManifestURL string `json:"manifestUrl"`
+	Token       string `json:"token,omitempty"`
 }
 
 // ClusterRegistrationTokenList contains a list of ClusterRegistrationTokens.
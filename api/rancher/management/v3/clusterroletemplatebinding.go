@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterRoleTemplateBinding is the struct representing a Rancher ClusterRoleTemplateBinding.
+// +kubebuilder:object:root=true
+type ClusterRoleTemplateBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	ClusterName        string `json:"clusterName"`
+	RoleTemplateName   string `json:"roleTemplateName"`
+	UserName           string `json:"userName,omitempty"`
+	UserPrincipalName  string `json:"userPrincipalName,omitempty"`
+	GroupName          string `json:"groupName,omitempty"`
+	GroupPrincipalName string `json:"groupPrincipalName,omitempty"`
+}
+
+// ClusterRoleTemplateBindingList contains a list of ClusterRoleTemplateBindings.
+// +kubebuilder:object:root=true
+type ClusterRoleTemplateBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterRoleTemplateBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRoleTemplateBinding{}, &ClusterRoleTemplateBindingList{})
+}
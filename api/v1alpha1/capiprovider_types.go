@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	operatorv1 "sigs.k8s.io/cluster-api-operator/api/v1alpha2"
@@ -65,6 +66,101 @@ type CAPIProviderSpec struct {
 	// EnableAutomaticUpdate can be used to automatically update the CAPIProvider to a newest version.
 	// +optional
 	EnableAutomaticUpdate bool `json:"enableAutomaticUpdate,omitempty"`
+
+	// MaintenanceWindow restricts automatic updates to a recurring time window. When unset, automatic updates
+	// are applied as soon as a new version is available.
+	// +optional
+	// +kubebuilder:example={schedule: "0 2 * * 6", duration: "4h", timeZone: "Europe/Berlin"}
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Controller tunes the provider controller. Its settings are translated into the manager and deployment fields of
+	// the provider spec, taking precedence over them. Settings removed from the controller are removed from these fields.
+	// +optional
+	// +kubebuilder:example={replicas: 2, concurrency: 10, verbosity: 4, featureGates: {MachinePool: true}}
+	Controller *ControllerSpec `json:"controller,omitempty"`
+
+	// DependsOn lists the providers this provider depends on. The provider is installed and upgraded once all
+	// its dependencies are ready, and is deleted before them.
+	// +optional
+	// +kubebuilder:example={{name: rke2, type: bootstrap}}
+	DependsOn []ProviderReference `json:"dependsOn,omitempty"`
+
+	// UpgradeStrategy makes automatic updates wait for the provider to become healthy, and roll back to the
+	// previous version when it doesn't. When unset, automatic updates are rolled out without health checks.
+	// +optional
+	// +kubebuilder:example={timeout: "15m"}
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+}
+
+// ControllerSpec defines the tuning of the provider controller Deployment.
+type ControllerSpec struct {
+	// Replicas is the number of replicas of the controller Deployment.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int `json:"replicas,omitempty"`
+
+	// Resources are the compute resources of the manager container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Concurrency is the maximum number of concurrent reconciles of each provider controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Concurrency *int `json:"concurrency,omitempty"`
+
+	// Verbosity is the log verbosity of the manager.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Verbosity *int `json:"verbosity,omitempty"`
+
+	// FeatureGates enables or disables the provider feature gates.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// ExtraArgs are additional arguments of the manager, keyed by the flag with its leading dashes.
+	// +optional
+	// +kubebuilder:validation:XValidation:message="Extra args should be flags starting with '--'.",rule="self.all(k, k.startsWith('--'))"
+	// +kubebuilder:example={"--sync-period": "10m"}
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// ProviderReference references a CAPIProvider by its provider name and type.
+type ProviderReference struct {
+	// Name is the name of the provider, as in the spec.name of the CAPIProvider, or its name when spec.name is not set.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:example=rke2
+	Name string `json:"name"`
+
+	// Type is the type of the provider.
+	// +kubebuilder:example=bootstrap
+	Type Type `json:"type"`
+}
+
+// MaintenanceWindow defines a recurring time window for automatic provider updates.
+type MaintenanceWindow struct {
+	// Schedule is the cron expression of the window start, with the minute, hour, day of month, month and day of week fields.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:example="0 2 * * 6"
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open after each start.
+	// +kubebuilder:example="4h"
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA name of the time zone of the schedule. Defaults to UTC.
+	// +optional
+	// +kubebuilder:example="Europe/Berlin"
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// UpgradeStrategy defines a health-gated upgrade of the provider with an automatic rollback.
+type UpgradeStrategy struct {
+	// Timeout is the time given to the provider Deployments to become Available and to the provider CRDs
+	// to be established after an upgrade, before the provider is rolled back to the previous version.
+	// +optional
+	// +kubebuilder:default="10m"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 // Features defines a collection of features for the CAPI Provider to apply.
@@ -124,6 +220,31 @@ type CAPIProviderStatus struct {
 
 	// Name reflects actual provider name, which will be visible to users in 'kubectl get capiproviders -A -o wide'
 	Name string `json:"name,omitempty"`
+
+	// Upgrade is the provider upgrade in progress under the upgrade strategy.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Controller is the controller tuning last translated into the provider spec.
+	// +optional
+	Controller *ControllerSpec `json:"controller,omitempty"`
+
+	// FailedVersion is the last version rolled back by the upgrade strategy. The provider is not automatically
+	// updated to this version again.
+	// +optional
+	FailedVersion string `json:"failedVersion,omitempty"`
+}
+
+// UpgradeStatus defines the observed state of a provider upgrade under the upgrade strategy.
+type UpgradeStatus struct {
+	// PreviousVersion is the version the provider is rolled back to when the upgrade fails.
+	PreviousVersion string `json:"previousVersion"`
+
+	// Version is the version the provider is upgraded to.
+	Version string `json:"version"`
+
+	// StartTime is the time the upgrade started.
+	StartTime metav1.Time `json:"startTime"`
 }
 
 // CAPIProvider is the Schema for the CAPI Providers API.
@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterImportPolicySpec defines which CAPI clusters in the namespace are imported into Rancher,
+// and the defaults applied to them. Labels and annotations set on a cluster or its namespace take precedence.
+type ClusterImportPolicySpec struct {
+	// ClusterSelector selects the CAPI clusters in the policy namespace to import into Rancher.
+	// An empty selector selects all clusters in the namespace.
+	// +required
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// Description is the default Rancher cluster description.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// FleetWorkspace is the default Fleet workspace of the Rancher cluster.
+	// +optional
+	FleetWorkspace string `json:"fleetWorkspace,omitempty"`
+
+	// Agent configures how the Rancher agent is installed on the selected clusters.
+	// +optional
+	Agent ImportAgentSpec `json:"agent,omitempty"`
+
+	// DeletionPolicy defines what happens to the Rancher cluster when the CAPI cluster is deleted,
+	// and to the CAPI cluster import when the Rancher cluster is deleted. Defaults to Cascade.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DeletionPolicy defines how the deletion of one side of an imported cluster affects the other side.
+// +kubebuilder:validation:Enum=Cascade;Orphan;Detach
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyCascade deletes the Rancher cluster with the CAPI cluster.
+	// When the Rancher cluster is deleted, the CAPI cluster is not imported again.
+	DeletionPolicyCascade DeletionPolicy = "Cascade"
+
+	// DeletionPolicyOrphan keeps the Rancher cluster when the CAPI cluster is deleted, releasing it from Turtles.
+	// When the Rancher cluster is deleted, the CAPI cluster is imported again.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+
+	// DeletionPolicyDetach keeps the Rancher cluster when the CAPI cluster is deleted, releasing it from Turtles.
+	// When the Rancher cluster is deleted, the CAPI cluster is not imported again and the Rancher agent
+	// is kept on the workload cluster.
+	DeletionPolicyDetach DeletionPolicy = "Detach"
+)
+
+// ImportAgentSpec defines how the Rancher agent is installed on imported clusters.
+type ImportAgentSpec struct {
+	// Mode selects how the import manifest is delivered to the selected clusters. Defaults to Push.
+	// +optional
+	Mode ImportMode `json:"mode,omitempty"`
+
+	// ServerSideApply applies the import manifest with server-side apply, repairing drift of the existing agent objects.
+	// Defaults to the controller setting.
+	// +optional
+	ServerSideApply *bool `json:"serverSideApply,omitempty"`
+
+	// Tolerations are added to the cattle-cluster-agent Deployment.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeSelector is merged into the node selector of the cattle-cluster-agent Deployment.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// PriorityClassName is the priority class of the cattle-cluster-agent Deployment.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ImageRegistry replaces the registry of the cattle-cluster-agent images, e.g. registry.example.com/mirror.
+	// +optional
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// Proxy sets the proxy environment variables of the cattle-cluster-agent.
+	// +optional
+	Proxy *AgentProxySpec `json:"proxy,omitempty"`
+
+	// AdditionalTrustedCAsSecret is the name of a Secret in the cattle-system namespace of the downstream cluster,
+	// holding additional CA certificates in its ca-additional.pem key. It is mounted into the cattle-cluster-agent.
+	// +optional
+	AdditionalTrustedCAsSecret string `json:"additionalTrustedCAsSecret,omitempty"`
+}
+
+// ImportMode defines how the Rancher import manifest is delivered to the workload cluster.
+// +kubebuilder:validation:Enum=Push;Pull
+type ImportMode string
+
+const (
+	// ImportModePush applies the import manifest to the workload cluster from Turtles,
+	// using the CAPI kubeconfig Secret of the cluster.
+	ImportModePush ImportMode = "Push"
+
+	// ImportModePull delivers the import manifest with a ClusterResourceSet generated by Turtles, without Turtles
+	// accessing the workload cluster. The agent deployment is tracked with the AgentDeployed condition of the
+	// Rancher cluster. Requires the ClusterResourceSet feature of the CAPI core provider.
+	ImportModePull ImportMode = "Pull"
+)
+
+// AgentProxySpec defines the proxy used by the Rancher agent.
+type AgentProxySpec struct {
+	// HTTPProxy is the value of the HTTP_PROXY environment variable.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the value of the HTTPS_PROXY environment variable.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the value of the NO_PROXY environment variable.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// ClusterImportPolicy is the Schema for the CAPI cluster import policy API.
+// When several policies select a cluster, the first one by name is used.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterimportpolicies,shortName=cip
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterImportPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterImportPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterImportPolicyList contains a list of ClusterImportPolicies.
+type ClusterImportPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterImportPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterImportPolicy{}, &ClusterImportPolicyList{})
+}
@@ -34,6 +34,22 @@ const (
 
 	// CAPIProviderWranglerManagedCertificatesCondition is the condittion used when provider certificates managed by wrangler.
 	CAPIProviderWranglerManagedCertificatesCondition = "WranglerManagedCertificates"
+
+	// ProviderUpgradeRolledBackCondition is set on the CAPIProvider when an upgrade under the upgrade strategy
+	// didn't become healthy in time and the provider was rolled back to the previous version.
+	ProviderUpgradeRolledBackCondition = "ProviderUpgradeRolledBack"
+
+	// ContractCompatibleCondition is set on the CAPIProvider and reports whether the CAPI contract of the provider
+	// version is compatible with the installed core provider and with Turtles. Incompatible versions are not installed.
+	ContractCompatibleCondition = "ContractCompatible"
+
+	// DependenciesReadyCondition is set on the CAPIProvider with dependencies and reports whether the providers
+	// it depends on are ready. The provider is not installed or upgraded until they are.
+	DependenciesReadyCondition = "DependenciesReady"
+
+	// DependentsDeletedCondition is set on the deleted CAPIProvider and reports whether the providers depending on it
+	// are deleted. The provider is not deleted until they are.
+	DependentsDeletedCondition = "DependentsDeleted"
 )
 
 const (
@@ -42,4 +58,131 @@ const (
 
 	// CheckLatestProviderUnknownReason is a reason for an Unknown condition, due to provider not being available.
 	CheckLatestProviderUnknownReason = "ProviderUnknown"
+
+	// CheckLatestUpdateRolledBackReason is a reason for a False condition, due to the latest version being rolled back.
+	CheckLatestUpdateRolledBackReason = "UpdateRolledBack"
+
+	// CheckLatestUpdatePendingReason is a reason for a False condition, due to the update waiting for the maintenance window.
+	CheckLatestUpdatePendingReason = "UpdatePending"
+
+	// CheckLatestInvalidMaintenanceWindowReason is a reason for a False condition, due to an invalid maintenance window.
+	CheckLatestInvalidMaintenanceWindowReason = "InvalidMaintenanceWindow"
+)
+
+const (
+	// ProviderUpgradeTimedOutReason is a reason for a True condition, due to the upgraded provider not becoming healthy
+	// within the upgrade strategy timeout.
+	ProviderUpgradeTimedOutReason = "UpgradeTimedOut"
+
+	// ProviderUpgradeSucceededReason is a reason for a False condition, when the upgraded provider became healthy.
+	ProviderUpgradeSucceededReason = "UpgradeSucceeded"
+)
+
+const (
+	// ContractCompatibleReason is a reason for a True condition, when the provider contract is compatible.
+	ContractCompatibleReason = "Compatible"
+
+	// ContractReleaseSeriesMissingReason is a reason for a False condition, due to the provider metadata not having
+	// a release series for the provider version.
+	ContractReleaseSeriesMissingReason = "ReleaseSeriesMissing"
+
+	// ContractIncompatibleReason is a reason for a False condition, due to the provider contract not being compatible
+	// with the contract of the installed core provider.
+	ContractIncompatibleReason = "ContractIncompatible"
+
+	// ContractUnsupportedReason is a reason for a False condition, due to the provider contract not being supported by Turtles.
+	ContractUnsupportedReason = "ContractUnsupported"
+)
+
+const (
+	// DependenciesReadyReason is a reason for a True condition, when all the provider dependencies are ready.
+	DependenciesReadyReason = "DependenciesReady"
+
+	// DependencyNotFoundReason is a reason for a False condition, due to a provider dependency not existing.
+	DependencyNotFoundReason = "DependencyNotFound"
+
+	// DependencyNotReadyReason is a reason for a False condition, due to a provider dependency not being ready
+	// or still being upgraded.
+	DependencyNotReadyReason = "DependencyNotReady"
+
+	// DependencyCycleReason is a reason for a False condition, due to the provider dependencies forming a cycle.
+	DependencyCycleReason = "DependencyCycle"
+)
+
+const (
+	// DependentsDeletedReason is a reason for a True condition, when no provider depends on the deleted provider.
+	DependentsDeletedReason = "DependentsDeleted"
+
+	// DependentsNotDeletedReason is a reason for a False condition, due to the deleted provider waiting for the providers
+	// depending on it to be deleted first.
+	DependentsNotDeletedReason = "DependentsNotDeleted"
+
+	// DependentCycleIgnoredReason is a reason for a True condition, when the providers depending on the deleted provider
+	// are also its dependencies. They form a cycle and are not waited for.
+	DependentCycleIgnoredReason = "DependentCycleIgnored"
+)
+
+const (
+	// RancherImportedCondition is set on the CAPI Cluster and reports on the progress of its import into Rancher.
+	RancherImportedCondition = "RancherImported"
+)
+
+const (
+	// RancherImportWaitingForControlPlaneReason is a reason for a False condition, due to the cluster control plane not being available yet.
+	RancherImportWaitingForControlPlaneReason = "WaitingForControlPlane"
+
+	// RancherImportWaitingForRegistrationTokenReason is a reason for a False condition, due to the Rancher registration manifest not being generated yet.
+	RancherImportWaitingForRegistrationTokenReason = "WaitingForRegistrationToken"
+
+	// RancherImportWaitingForCleanupReason is a reason for a False condition, due to a previous agent installation still being removed.
+	RancherImportWaitingForCleanupReason = "WaitingForCleanup"
+
+	// RancherImportManifestAppliedReason is a reason for a False condition, due to the Rancher agent not being connected yet after applying the import manifest.
+	RancherImportManifestAppliedReason = "ManifestApplied"
+
+	// RancherImportAgentDisconnectedReason is a reason for a False condition, due to a previously connected Rancher agent being disconnected.
+	RancherImportAgentDisconnectedReason = "AgentDisconnected"
+
+	// RancherImportAgentConnectedReason is a reason for a True condition, when the fleet agent namespace is still being migrated.
+	RancherImportAgentConnectedReason = "AgentConnected"
+
+	// RancherImportFleetMigratedReason is a reason for a True condition, when the cluster is fully imported.
+	RancherImportFleetMigratedReason = "FleetMigrated"
+
+	// RancherImportAgentCleanupReason is a reason for a False condition, when the Rancher agent is being removed
+	// from the cluster after the Rancher cluster was deleted.
+	RancherImportAgentCleanupReason = "AgentCleanup"
+
+	// RancherImportManifestDownloadFailedReason is a reason for a False condition, due to the registration manifest
+	// failing to download from Rancher.
+	RancherImportManifestDownloadFailedReason = "ManifestDownloadFailed"
+
+	// RancherImportInvalidSystemAgentReason is a reason for a False condition, due to an invalid system-agent install source
+	// requested with the upstream-system-agent or local-system-agent annotation.
+	RancherImportInvalidSystemAgentReason = "InvalidSystemAgent"
+
+	// RancherImportManifestDeliveredReason is a reason for a False condition, when the import manifest is delivered
+	// with a ClusterResourceSet and the Rancher agent is not deployed yet.
+	RancherImportManifestDeliveredReason = "ManifestDelivered"
+
+	// RancherImportAgentDeployedReason is a reason for a False condition, when the Rancher agent delivered
+	// with a ClusterResourceSet is deployed and not connected yet.
+	RancherImportAgentDeployedReason = "AgentDeployed"
+
+	// RancherImportFailedReason is a reason for a False condition, due to an error during the import.
+	RancherImportFailedReason = "ImportFailed"
+)
+
+const (
+	// RancherClusterUniqueCondition is set on the CAPI Cluster and reports whether it is imported as a single Rancher cluster.
+	RancherClusterUniqueCondition = "RancherClusterUnique"
+)
+
+const (
+	// RancherClusterUniqueReason is a reason for a True condition, when a single Rancher cluster is found for the CAPI cluster.
+	RancherClusterUniqueReason = "Unique"
+
+	// RancherClusterDuplicatesRemovedReason is a reason for a False condition, when duplicate Rancher clusters
+	// were found for the CAPI cluster and removed.
+	RancherClusterDuplicatesRemovedReason = "DuplicatesRemoved"
 )
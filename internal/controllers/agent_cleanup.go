@@ -0,0 +1,155 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+// agentObjectsKey is the ConfigMap key holding the references of the objects created by the import manifest.
+const agentObjectsKey = "objects"
+
+// agentObjectsConfigMapName returns the name of the ConfigMap recording the Rancher agent objects of the CAPI cluster.
+func agentObjectsConfigMapName(capiCluster *clusterv1.Cluster) string {
+	return capiCluster.Name + "-rancher-agent"
+}
+
+// agentObjectReferences strips the import manifest down to the kind, name and namespace of every object,
+// leaving out the registration token and any other data.
+func agentObjectReferences(manifest string) (string, error) {
+	items, err := utilyaml.ToUnstructured([]byte(manifest))
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling import manifest: %w", err)
+	}
+
+	refs := []unstructured.Unstructured{}
+
+	for _, obj := range items {
+		ref := unstructured.Unstructured{}
+		ref.SetAPIVersion(obj.GetAPIVersion())
+		ref.SetKind(obj.GetKind())
+		ref.SetName(obj.GetName())
+		ref.SetNamespace(obj.GetNamespace())
+
+		refs = append(refs, ref)
+	}
+
+	out, err := utilyaml.FromUnstructured(refs)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling agent objects: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// recordAgentObjects stores the references of the objects created by the import manifest next to the CAPI cluster,
+// so the agent can be removed after the Rancher cluster and its registration token are gone.
+func (r *CAPIImportReconciler) recordAgentObjects(ctx context.Context, capiCluster *clusterv1.Cluster, manifest string) error {
+	objects, err := agentObjectReferences(manifest)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentObjectsConfigMapName(capiCluster),
+			Namespace: capiCluster.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, cm, func() error {
+		cm.Labels = map[string]string{
+			capiClusterOwner:          capiCluster.Name,
+			capiClusterOwnerNamespace: capiCluster.Namespace,
+			ownedLabelName:            "",
+		}
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+			Name:       capiCluster.Name,
+			UID:        capiCluster.UID,
+		}}
+		cm.Data = map[string]string{agentObjectsKey: objects}
+
+		return nil
+	})
+
+	return err
+}
+
+// cleanupAgent deletes the recorded Rancher agent objects from the downstream cluster. It returns true
+// while the objects are being deleted. Clusters without recorded objects are skipped.
+func (r *CAPIImportReconciler) cleanupAgent(ctx context.Context, capiCluster *clusterv1.Cluster) (bool, error) {
+	log := log.FromContext(ctx)
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: capiCluster.Namespace,
+		Name:      agentObjectsConfigMapName(capiCluster),
+	}, cm); apierrors.IsNotFound(err) {
+		log.Info("No recorded Rancher agent objects, skipping agent cleanup")
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting recorded agent objects: %w", err)
+	}
+
+	items, err := utilyaml.ToUnstructured([]byte(cm.Data[agentObjectsKey]))
+	if err != nil {
+		return false, fmt.Errorf("error unmarshalling recorded agent objects: %w", err)
+	}
+
+	remoteClient, err := r.remoteClientGetter(ctx, capiCluster.Name, r.Client, client.ObjectKeyFromObject(capiCluster))
+	if err != nil {
+		return false, fmt.Errorf("getting remote cluster client: %w", err)
+	}
+
+	deleting := false
+
+	for _, obj := range items {
+		err := remoteClient.Delete(ctx, &obj)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("deleting %s %s in remote cluster: %w", obj.GetKind(), client.ObjectKeyFromObject(&obj), err)
+		}
+
+		log.V(4).Info("agent object is being deleted, waiting", "gvk", obj.GroupVersionKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+		deleting = true
+	}
+
+	if deleting {
+		return true, nil
+	}
+
+	log.Info("Rancher agent was removed from the cluster")
+
+	return false, client.IgnoreNotFound(r.Client.Delete(ctx, cm))
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Rancher agent cleanup", func() {
+	const manifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cattle-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cattle
+  namespace: cattle-system
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: cattle-credentials
+  namespace: cattle-system
+data:
+  token: c2VjcmV0
+`
+
+	var (
+		r            *CAPIImportReconciler
+		fakeClient   client.Client
+		remoteClient client.Client
+		capiCluster  *clusterv1.Cluster
+		secret       *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+				UID:       "1234",
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cattle-credentials",
+				Namespace:  "cattle-system",
+				Finalizers: []string{"example.com/wait"},
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithObjects(capiCluster).Build()
+		remoteClient = fake.NewClientBuilder().WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cattle-system"}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "cattle", Namespace: "cattle-system"}},
+			secret,
+		).Build()
+
+		r = &CAPIImportReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(10),
+			remoteClientGetter: func(context.Context, string, client.Client, client.ObjectKey) (client.Client, error) {
+				return remoteClient, nil
+			},
+		}
+	})
+
+	It("should only record the references of the agent objects", func() {
+		Expect(r.recordAgentObjects(ctx, capiCluster, manifest)).To(Succeed())
+
+		cm := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "cluster-rancher-agent"}, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.OwnerReferences[0].UID).To(Equal(capiCluster.UID))
+		Expect(cm.OwnerReferences[0].BlockOwnerDeletion).To(BeNil())
+		Expect(cm.Data[agentObjectsKey]).To(ContainSubstring("name: cattle-credentials"))
+		Expect(cm.Data[agentObjectsKey]).ToNot(ContainSubstring("c2VjcmV0"))
+	})
+
+	It("should skip clusters without recorded agent objects", func() {
+		Expect(r.cleanupAgent(ctx, capiCluster)).To(BeFalse())
+		Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	})
+
+	It("should wait for the agent objects to be deleted", func() {
+		Expect(r.recordAgentObjects(ctx, capiCluster, manifest)).To(Succeed())
+
+		Expect(r.cleanupAgent(ctx, capiCluster)).To(BeTrue())
+
+		err := remoteClient.Get(ctx, client.ObjectKey{Name: "cattle", Namespace: "cattle-system"}, &corev1.ServiceAccount{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(secret.DeletionTimestamp.IsZero()).To(BeFalse())
+
+		secret.Finalizers = nil
+		Expect(remoteClient.Update(ctx, secret)).To(Succeed())
+
+		Expect(r.cleanupAgent(ctx, capiCluster)).To(BeFalse())
+
+		err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "cluster-rancher-agent"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Rancher cluster cleanup", func() {
+	var (
+		r              *CAPICleanupReconciler
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+	)
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+			},
+		}
+		rancherCluster = &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "c-abcde",
+				Labels: map[string]string{
+					capiClusterOwner:          capiCluster.Name,
+					capiClusterOwnerNamespace: capiCluster.Namespace,
+					ownedLabelName:            "",
+				},
+				Finalizers: []string{managementv3.CapiClusterFinalizer, managementv3.AgentCleanupFinalizer, "example.com/keep"},
+			},
+		}
+	})
+
+	reconcileDeleted := func(objects ...client.Object) {
+		fakeClient := fake.NewClientBuilder().WithObjects(append(objects, rancherCluster)...).Build()
+		r = &CAPICleanupReconciler{Client: fakeClient, RancherClient: fakeClient}
+
+		Expect(fakeClient.Delete(ctx, rancherCluster)).To(Succeed())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, rancherCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+	}
+
+	It("should keep the agent cleanup finalizer while the CAPI cluster is imported", func() {
+		reconcileDeleted(capiCluster)
+		Expect(rancherCluster.Finalizers).To(ConsistOf(managementv3.AgentCleanupFinalizer, "example.com/keep"))
+	})
+
+	It("should release the agent cleanup finalizer when the CAPI cluster is gone", func() {
+		reconcileDeleted()
+		Expect(rancherCluster.Finalizers).To(ConsistOf("example.com/keep"))
+	})
+
+	It("should keep the finalizers while the CAPI cluster is paused", func() {
+		capiCluster.Annotations = map[string]string{
+			turtlesannotations.ClusterImportedAnnotation: "true",
+			turtlesannotations.PausedAnnotation:          "true",
+		}
+		reconcileDeleted(capiCluster)
+		Expect(rancherCluster.Finalizers).To(ConsistOf(
+			managementv3.CapiClusterFinalizer, managementv3.AgentCleanupFinalizer, "example.com/keep"))
+	})
+
+	It("should release the agent cleanup finalizer when the CAPI cluster is no longer imported", func() {
+		capiCluster.Annotations = map[string]string{turtlesannotations.ClusterImportedAnnotation: "true"}
+		reconcileDeleted(capiCluster)
+		Expect(rancherCluster.Finalizers).To(ConsistOf("example.com/keep"))
+	})
+})
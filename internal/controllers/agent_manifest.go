@@ -0,0 +1,257 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+const (
+	agentDeploymentName = "cattle-cluster-agent"
+	agentNamespace      = "cattle-system"
+
+	additionalCAsVolumeName = "tls-ca-additional-volume"
+	additionalCAsKey        = "ca-additional.pem"
+	additionalCAsMountPath  = "/etc/ssl/certs/ca-additional.pem"
+
+	upstreamSystemAgentReleases = "https://github.com/rancher/system-agent/releases"
+)
+
+var systemAgentVersionRegexp = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+
+// agentManifestAlterFn alters the objects of the import manifest before they are applied to the downstream cluster.
+type agentManifestAlterFn func(objs []unstructured.Unstructured) ([]unstructured.Unstructured, error)
+
+// agentManifestAlterFns returns the alterations of the import manifest requested by the agent configuration.
+func agentManifestAlterFns(agent turtlesv1.ImportAgentSpec) []agentManifestAlterFn {
+	alterFuncs := []agentManifestAlterFn{}
+
+	if len(agent.Tolerations) > 0 {
+		alterFuncs = append(alterFuncs, alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+			podSpec.Tolerations = append(podSpec.Tolerations, agent.Tolerations...)
+		}))
+	}
+
+	if len(agent.NodeSelector) > 0 {
+		alterFuncs = append(alterFuncs, alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+			if podSpec.NodeSelector == nil {
+				podSpec.NodeSelector = map[string]string{}
+			}
+
+			maps.Copy(podSpec.NodeSelector, agent.NodeSelector)
+		}))
+	}
+
+	if agent.PriorityClassName != "" {
+		alterFuncs = append(alterFuncs, alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+			podSpec.PriorityClassName = agent.PriorityClassName
+		}))
+	}
+
+	if agent.ImageRegistry != "" {
+		alterFuncs = append(alterFuncs, alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+			for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+				for i := range containers {
+					containers[i].Image = rewriteImageRegistry(containers[i].Image, agent.ImageRegistry)
+				}
+			}
+		}))
+	}
+
+	if agent.Proxy != nil {
+		alterFuncs = append(alterFuncs, setAgentEnv(
+			corev1.EnvVar{Name: "HTTP_PROXY", Value: agent.Proxy.HTTPProxy},
+			corev1.EnvVar{Name: "HTTPS_PROXY", Value: agent.Proxy.HTTPSProxy},
+			corev1.EnvVar{Name: "NO_PROXY", Value: agent.Proxy.NoProxy},
+		))
+	}
+
+	if agent.AdditionalTrustedCAsSecret != "" {
+		alterFuncs = append(alterFuncs, alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: additionalCAsVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: agent.AdditionalTrustedCAsSecret},
+				},
+			})
+
+			for i := range podSpec.Containers {
+				podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+					Name:      additionalCAsVolumeName,
+					MountPath: additionalCAsMountPath,
+					SubPath:   additionalCAsKey,
+					ReadOnly:  true,
+				})
+			}
+		}))
+	}
+
+	return alterFuncs
+}
+
+// validateSystemAgent validates the system-agent install source requested with the upstream-system-agent
+// or local-system-agent annotation of the CAPI cluster.
+func validateSystemAgent(capiCluster *clusterv1.Cluster) error {
+	annotations := capiCluster.GetAnnotations()
+	version, upstream := annotations[turtlesannotations.UpstreamSystemAgentAnnotation]
+	location, local := annotations[turtlesannotations.LocalSystemAgentAnnotation]
+
+	switch {
+	case upstream && local:
+		return fmt.Errorf("annotations %s and %s are mutually exclusive",
+			turtlesannotations.UpstreamSystemAgentAnnotation, turtlesannotations.LocalSystemAgentAnnotation)
+	case upstream && version != "" && version != trueValue && !systemAgentVersionRegexp.MatchString(version):
+		return fmt.Errorf("invalid system-agent version %q in annotation %s, expected a release tag like v0.3.11 or true for the latest release",
+			version, turtlesannotations.UpstreamSystemAgentAnnotation)
+	case local && !path.IsAbs(location):
+		return fmt.Errorf("invalid system-agent location %q in annotation %s, expected an absolute path of the binary on the nodes",
+			location, turtlesannotations.LocalSystemAgentAnnotation)
+	default:
+		return nil
+	}
+}
+
+// systemAgentAlterFns returns the alteration of the system-agent install source requested with the
+// upstream-system-agent or local-system-agent annotation of the CAPI cluster. The install source is set
+// on the cattle-cluster-agent, which passes it to the system-agent install on the nodes. The annotations
+// are expected to be valid, see validateSystemAgent.
+func systemAgentAlterFns(capiCluster *clusterv1.Cluster) []agentManifestAlterFn {
+	annotations := capiCluster.GetAnnotations()
+
+	if version, upstream := annotations[turtlesannotations.UpstreamSystemAgentAnnotation]; upstream {
+		baseURL := upstreamSystemAgentReleases + "/latest/download"
+		if version != "" && version != trueValue {
+			baseURL = upstreamSystemAgentReleases + "/download/" + version
+		}
+
+		return []agentManifestAlterFn{setAgentEnv(corev1.EnvVar{Name: "CATTLE_AGENT_BINARY_BASE_URL", Value: baseURL})}
+	}
+
+	if location, local := annotations[turtlesannotations.LocalSystemAgentAnnotation]; local {
+		return []agentManifestAlterFn{setAgentEnv(
+			corev1.EnvVar{Name: "CATTLE_AGENT_BINARY_LOCAL", Value: trueValue},
+			corev1.EnvVar{Name: "CATTLE_AGENT_BINARY_LOCAL_LOCATION", Value: location},
+		)}
+	}
+
+	return nil
+}
+
+// alterAgentManifest applies the alter functions to the objects of the import manifest.
+// The manifest is returned unchanged when there is nothing to alter.
+func alterAgentManifest(manifest string, alterFuncs []agentManifestAlterFn) (string, error) {
+	if len(alterFuncs) == 0 {
+		return manifest, nil
+	}
+
+	objs, err := utilyaml.ToUnstructured([]byte(manifest))
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling import manifest: %w", err)
+	}
+
+	for _, alter := range alterFuncs {
+		if objs, err = alter(objs); err != nil {
+			return "", err
+		}
+	}
+
+	out, err := utilyaml.FromUnstructured(objs)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling import manifest: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// alterAgentDeployment returns an alter function changing the pod template of the cattle-cluster-agent Deployment.
+func alterAgentDeployment(alter func(podSpec *corev1.PodSpec)) agentManifestAlterFn {
+	return func(objs []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		for i := range objs {
+			o := &objs[i]
+			if o.GetKind() != "Deployment" || o.GetName() != agentDeploymentName || o.GetNamespace() != agentNamespace {
+				continue
+			}
+
+			deployment := &appsv1.Deployment{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.Object, deployment); err != nil {
+				return nil, fmt.Errorf("converting %s deployment: %w", agentDeploymentName, err)
+			}
+
+			alter(&deployment.Spec.Template.Spec)
+
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+			if err != nil {
+				return nil, fmt.Errorf("converting %s deployment: %w", agentDeploymentName, err)
+			}
+
+			unstructured.RemoveNestedField(obj, "status")
+			o.Object = obj
+		}
+
+		return objs, nil
+	}
+}
+
+// setAgentEnv returns an alter function setting the non-empty environment variables on the cattle-cluster-agent containers.
+func setAgentEnv(env ...corev1.EnvVar) agentManifestAlterFn {
+	return alterAgentDeployment(func(podSpec *corev1.PodSpec) {
+		for i := range podSpec.Containers {
+			for _, envVar := range env {
+				if envVar.Value != "" {
+					podSpec.Containers[i].Env = setEnvVar(podSpec.Containers[i].Env, envVar)
+				}
+			}
+		}
+	})
+}
+
+// rewriteImageRegistry replaces the registry of the image, keeping the repository and tag.
+func rewriteImageRegistry(image, registry string) string {
+	repository := image
+	if host, rest, found := strings.Cut(image, "/"); found && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		repository = rest
+	}
+
+	return strings.TrimSuffix(registry, "/") + "/" + repository
+}
+
+// setEnvVar sets the environment variable, replacing an existing one with the same name.
+func setEnvVar(env []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == envVar.Name {
+			env[i] = envVar
+			return env
+		}
+	}
+
+	return append(env, envVar)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Import manifest alteration", func() {
+	const manifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cattle-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cattle-cluster-agent
+  namespace: cattle-system
+spec:
+  selector:
+    matchLabels:
+      app: cattle-cluster-agent
+  template:
+    metadata:
+      labels:
+        app: cattle-cluster-agent
+    spec:
+      nodeSelector:
+        kubernetes.io/os: linux
+      tolerations:
+      - effect: NoSchedule
+        key: node-role.kubernetes.io/control-plane
+      containers:
+      - name: cluster-register
+        image: rancher/rancher-agent:v2.12.0
+        env:
+        - name: HTTPS_PROXY
+          value: http://old-proxy:3128
+`
+
+	deploymentFrom := func(alterFuncs []agentManifestAlterFn) *appsv1.Deployment {
+		altered, err := alterAgentManifest(manifest, alterFuncs)
+		Expect(err).ToNot(HaveOccurred())
+
+		objs, err := utilyaml.ToUnstructured([]byte(altered))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objs).To(HaveLen(2))
+
+		deployment := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objs[1].Object, deployment)).To(Succeed())
+
+		return deployment
+	}
+
+	alteredDeployment := func(agent turtlesv1.ImportAgentSpec) *appsv1.Deployment {
+		return deploymentFrom(agentManifestAlterFns(agent))
+	}
+
+	It("should leave the manifest unchanged without agent configuration", func() {
+		Expect(alterAgentManifest(manifest, agentManifestAlterFns(turtlesv1.ImportAgentSpec{}))).To(Equal(manifest))
+	})
+
+	It("should add scheduling constraints to the agent deployment", func() {
+		podSpec := alteredDeployment(turtlesv1.ImportAgentSpec{
+			Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": "true"},
+			PriorityClassName: "system-cluster-critical",
+		}).Spec.Template.Spec
+
+		Expect(podSpec.Tolerations).To(HaveLen(2))
+		Expect(podSpec.Tolerations[1].Key).To(Equal("dedicated"))
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{
+			"kubernetes.io/os":              "linux",
+			"node-role.kubernetes.io/infra": "true",
+		}))
+		Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+	})
+
+	It("should rewrite the agent image registry", func() {
+		podSpec := alteredDeployment(turtlesv1.ImportAgentSpec{ImageRegistry: "registry.example.com/mirror/"}).Spec.Template.Spec
+
+		Expect(podSpec.Containers[0].Image).To(Equal("registry.example.com/mirror/rancher/rancher-agent:v2.12.0"))
+		Expect(rewriteImageRegistry("registry.local:5000/rancher/rancher-agent:v2.12.0", "mirror.example.com")).
+			To(Equal("mirror.example.com/rancher/rancher-agent:v2.12.0"))
+	})
+
+	It("should set the proxy environment variables", func() {
+		podSpec := alteredDeployment(turtlesv1.ImportAgentSpec{Proxy: &turtlesv1.AgentProxySpec{
+			HTTPSProxy: "http://proxy:3128",
+			NoProxy:    "127.0.0.1,.svc",
+		}}).Spec.Template.Spec
+
+		Expect(podSpec.Containers[0].Env).To(ConsistOf(
+			corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+			corev1.EnvVar{Name: "NO_PROXY", Value: "127.0.0.1,.svc"},
+		))
+	})
+
+	It("should mount the additional trusted CAs", func() {
+		podSpec := alteredDeployment(turtlesv1.ImportAgentSpec{AdditionalTrustedCAsSecret: "tls-ca-additional"}).Spec.Template.Spec
+
+		Expect(podSpec.Volumes).To(HaveLen(1))
+		Expect(podSpec.Volumes[0].Secret.SecretName).To(Equal("tls-ca-additional"))
+		Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name:      additionalCAsVolumeName,
+			MountPath: additionalCAsMountPath,
+			SubPath:   additionalCAsKey,
+			ReadOnly:  true,
+		}))
+	})
+
+	Context("with system-agent annotations", func() {
+		validate := func(annotations map[string]string) error {
+			return validateSystemAgent(&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}})
+		}
+
+		DescribeTable("should set the install source on the agent",
+			func(annotations map[string]string, env []corev1.EnvVar) {
+				capiCluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+				Expect(validateSystemAgent(capiCluster)).To(Succeed())
+
+				Expect(deploymentFrom(systemAgentAlterFns(capiCluster)).Spec.Template.Spec.Containers[0].Env).To(ConsistOf(
+					append([]corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://old-proxy:3128"}}, env...),
+				))
+			},
+			Entry("latest upstream release", map[string]string{turtlesannotations.UpstreamSystemAgentAnnotation: "true"}, []corev1.EnvVar{
+				{Name: "CATTLE_AGENT_BINARY_BASE_URL", Value: "https://github.com/rancher/system-agent/releases/latest/download"},
+			}),
+			Entry("upstream release", map[string]string{turtlesannotations.UpstreamSystemAgentAnnotation: "v0.3.11"}, []corev1.EnvVar{
+				{Name: "CATTLE_AGENT_BINARY_BASE_URL", Value: "https://github.com/rancher/system-agent/releases/download/v0.3.11"},
+			}),
+			Entry("node-local binary", map[string]string{turtlesannotations.LocalSystemAgentAnnotation: "/opt/bin/rancher-system-agent"}, []corev1.EnvVar{
+				{Name: "CATTLE_AGENT_BINARY_LOCAL", Value: "true"},
+				{Name: "CATTLE_AGENT_BINARY_LOCAL_LOCATION", Value: "/opt/bin/rancher-system-agent"},
+			}),
+		)
+
+		It("should leave the manifest unchanged without annotations", func() {
+			Expect(alterAgentManifest(manifest, systemAgentAlterFns(&clusterv1.Cluster{}))).To(Equal(manifest))
+		})
+
+		DescribeTable("should accept valid annotations",
+			func(annotations map[string]string) {
+				Expect(validate(annotations)).To(Succeed())
+			},
+			Entry("no annotations", nil),
+			Entry("latest upstream release", map[string]string{turtlesannotations.UpstreamSystemAgentAnnotation: "true"}),
+			Entry("upstream release", map[string]string{turtlesannotations.UpstreamSystemAgentAnnotation: "v0.3.11"}),
+			Entry("node-local binary", map[string]string{turtlesannotations.LocalSystemAgentAnnotation: "/opt/bin/rancher-system-agent"}),
+		)
+
+		DescribeTable("should reject invalid annotations",
+			func(annotations map[string]string, message string) {
+				Expect(validate(annotations)).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("invalid version", map[string]string{turtlesannotations.UpstreamSystemAgentAnnotation: "latest"}, "invalid system-agent version"),
+			Entry("relative location", map[string]string{turtlesannotations.LocalSystemAgentAnnotation: "bin/agent"}, "invalid system-agent location"),
+			Entry("both annotations", map[string]string{
+				turtlesannotations.UpstreamSystemAgentAnnotation: "true",
+				turtlesannotations.LocalSystemAgentAnnotation:    "/opt/bin/rancher-system-agent",
+			}, "mutually exclusive"),
+		)
+	})
+})
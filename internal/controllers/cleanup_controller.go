@@ -22,33 +22,52 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
 )
 
 // CAPICleanupReconciler is a reconciler for cleanup of managementv3 clusters.
 type CAPICleanupReconciler struct {
-	Client client.Client
-	Scheme *runtime.Scheme
+	Client        client.Client
+	RancherClient client.Client
+	RancherCache  cache.Cache
+	Scheme        *runtime.Scheme
 }
 
 // SetupWithManager sets up reconciler with manager.
 func (r *CAPICleanupReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, options controller.Options) error {
+	// Rancher Manager is running in the same cluster unless configured otherwise.
+	if r.RancherClient == nil {
+		r.RancherClient = r.Client
+	}
+
+	if r.RancherCache == nil {
+		r.RancherCache = mgr.GetCache()
+	}
+
 	if err := ctrl.NewControllerManagedBy(mgr).
 		Named("cleanup").
-		For(&managementv3.Cluster{}).
+		WatchesRawSource(source.Kind(r.RancherCache, &managementv3.Cluster{},
+			&handler.TypedEnqueueRequestForObject[*managementv3.Cluster]{},
+			predicate.NewTypedPredicateFuncs(func(object *managementv3.Cluster) bool {
+				_, exist := object.GetLabels()[ownedLabelName]
+				return exist
+			}),
+		)).
 		WithOptions(options).
-		WithEventFilter(predicate.NewPredicateFuncs(func(object client.Object) bool {
-			_, exist := object.GetLabels()[ownedLabelName]
-			return exist
-		})).
-		Complete(reconcile.AsReconciler(r.Client, r)); err != nil {
+		Complete(reconcile.AsReconciler(r.RancherClient, r)); err != nil {
 		return fmt.Errorf("creating new downgrade controller: %w", err)
 	}
 
@@ -56,17 +75,52 @@ func (r *CAPICleanupReconciler) SetupWithManager(_ context.Context, mgr ctrl.Man
 }
 
 // Reconcile performs check for clusters and removes finalizer on the clusters in deleteion
-// still containing the turtles finalizer.
+// still containing the turtles finalizer. The agent cleanup finalizer is only removed once the CAPI cluster is gone
+// or no longer imported, as the import controller releases it after removing the agent.
 func (r *CAPICleanupReconciler) Reconcile(ctx context.Context, cluster *managementv3.Cluster) (res ctrl.Result, err error) {
 	log := log.FromContext(ctx)
 
 	patchBase := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
 
-	if cluster.DeletionTimestamp.IsZero() || !controllerutil.RemoveFinalizer(cluster, managementv3.CapiClusterFinalizer) {
+	if cluster.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	capiCluster := &clusterv1.Cluster{}
+	capiClusterKey := client.ObjectKey{
+		Namespace: cluster.Labels[capiClusterOwnerNamespace],
+		Name:      cluster.Labels[capiClusterOwner],
+	}
+	capiClusterFound := false
+
+	if capiClusterKey.Name != "" {
+		err := r.Client.Get(ctx, capiClusterKey, capiCluster)
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+
+		capiClusterFound = err == nil
+	}
+
+	// The finalizers of the Rancher cluster are kept while the owning CAPI cluster is paused.
+	// Unpausing doesn't trigger this controller, so the cluster is checked again later.
+	if capiClusterFound && turtlesannotations.IsPaused(capiCluster) {
+		log.Info("CAPI cluster is paused, not removing finalizers", "cluster", capiClusterKey)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	removedFinalizer := controllerutil.RemoveFinalizer(cluster, managementv3.CapiClusterFinalizer)
+
+	if controllerutil.ContainsFinalizer(cluster, managementv3.AgentCleanupFinalizer) &&
+		(!capiClusterFound || !capiCluster.DeletionTimestamp.IsZero() || turtlesannotations.HasClusterImportAnnotation(capiCluster)) {
+		removedFinalizer = controllerutil.RemoveFinalizer(cluster, managementv3.AgentCleanupFinalizer) || removedFinalizer
+	}
+
+	if !removedFinalizer {
 		return
 	}
 
-	if err = r.Client.Patch(ctx, cluster, patchBase); err != nil {
+	if err = r.RancherClient.Patch(ctx, cluster, patchBase); err != nil {
 		log.Error(err, "Unable to remove turtles finalizer from cluster"+cluster.GetName())
 	}
 
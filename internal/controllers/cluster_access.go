@@ -0,0 +1,347 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	"github.com/rancher/turtles/util"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+// Subject kinds accepted in the cluster-role-bindings annotation.
+const (
+	subjectUser          = "user"
+	subjectUserPrincipal = "userPrincipal"
+	subjectGroup         = "group"
+)
+
+// clusterRoleBinding is a Rancher role template granted on the imported cluster to a single user or group.
+type clusterRoleBinding struct {
+	RoleTemplateName string
+	SubjectKind      string
+	Subject          string
+}
+
+// parseClusterRoleBindings parses a comma-separated list of `<roleTemplate>=<kind>:<subject>` entries,
+// where kind is one of user, userPrincipal or group. Subjects may contain ':', e.g. `group:github_team://1234`.
+func parseClusterRoleBindings(value string) ([]clusterRoleBinding, error) {
+	bindings := []clusterRoleBinding{}
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		role, subject, found := strings.Cut(entry, "=")
+		if !found || role == "" {
+			return nil, fmt.Errorf("invalid cluster role binding %q, expected <roleTemplate>=<kind>:<subject>", entry)
+		}
+
+		kind, name, found := strings.Cut(subject, ":")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid subject %q in cluster role binding %q, expected <kind>:<subject>", subject, entry)
+		}
+
+		switch kind {
+		case subjectUser, subjectUserPrincipal, subjectGroup:
+		default:
+			return nil, fmt.Errorf("invalid subject kind %q in cluster role binding %q, expected one of user, userPrincipal, group", kind, entry)
+		}
+
+		bindings = append(bindings, clusterRoleBinding{RoleTemplateName: role, SubjectKind: kind, Subject: name})
+	}
+
+	return bindings, nil
+}
+
+// parseProjects parses a comma-separated list of Rancher project display names.
+func parseProjects(value string) []string {
+	projects := []string{}
+
+	for name := range strings.SplitSeq(value, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(projects, name) {
+			projects = append(projects, name)
+		}
+	}
+
+	return projects
+}
+
+// name returns a stable name for the binding, so it is created only once in the Rancher cluster namespace.
+func (b clusterRoleBinding) name() string {
+	sum := sha256.Sum256([]byte(b.RoleTemplateName + "/" + b.SubjectKind + "/" + b.Subject))
+
+	return fmt.Sprintf("crtb-turtles-%x", sum[:5])
+}
+
+func (b clusterRoleBinding) toClusterRoleTemplateBinding(rancherCluster *managementv3.Cluster, labels map[string]string,
+) *managementv3.ClusterRoleTemplateBinding {
+	crtb := &managementv3.ClusterRoleTemplateBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.name(),
+			Namespace: rancherCluster.Name,
+			Labels:    labels,
+		},
+		ClusterName:      rancherCluster.Name,
+		RoleTemplateName: b.RoleTemplateName,
+	}
+
+	switch b.SubjectKind {
+	case subjectUser:
+		crtb.UserName = b.Subject
+	case subjectUserPrincipal:
+		crtb.UserPrincipalName = b.Subject
+	case subjectGroup:
+		crtb.GroupPrincipalName = b.Subject
+	}
+
+	return crtb
+}
+
+// accessOwnerLabels returns the labels identifying Rancher access objects created for the CAPI cluster.
+func accessOwnerLabels(capiCluster *clusterv1.Cluster) map[string]string {
+	return map[string]string{
+		capiClusterOwner:          capiCluster.Name,
+		capiClusterOwnerNamespace: capiCluster.Namespace,
+		ownedLabelName:            "",
+	}
+}
+
+// reconcileClusterAccess creates the Rancher projects and cluster role template bindings requested with annotations
+// on the CAPI cluster or its namespace. The bindings Turtles created which are no longer requested are removed,
+// and the projects are released.
+func (r *CAPIImportReconciler) reconcileClusterAccess(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherCluster *managementv3.Cluster,
+) error {
+	log := log.FromContext(ctx)
+
+	bindingsValue, err := util.ClusterOrNamespaceAnnotation(ctx, r.Client, capiCluster, turtlesannotations.ClusterRoleBindingsAnnotation)
+	if err != nil {
+		return err
+	}
+
+	projectsValue, err := util.ClusterOrNamespaceAnnotation(ctx, r.Client, capiCluster, turtlesannotations.ProjectsAnnotation)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+
+	// An invalid annotation leaves the existing bindings untouched until it is fixed.
+	if bindings, err := parseClusterRoleBindings(bindingsValue); err != nil {
+		log.Error(err, "Skipping cluster role bindings")
+		r.recorder.Event(capiCluster, corev1.EventTypeWarning, "InvalidClusterRoleBindings", err.Error())
+	} else if err := r.reconcileClusterRoleBindings(ctx, capiCluster, rancherCluster, bindings); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := r.reconcileProjects(ctx, capiCluster, rancherCluster, parseProjects(projectsValue)); err != nil {
+		errs = append(errs, err)
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (r *CAPIImportReconciler) reconcileClusterRoleBindings(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherCluster *managementv3.Cluster, bindings []clusterRoleBinding,
+) error {
+	log := log.FromContext(ctx)
+	labels := accessOwnerLabels(capiCluster)
+
+	existing := &managementv3.ClusterRoleTemplateBindingList{}
+	if err := r.RancherClient.List(ctx, existing, client.InNamespace(rancherCluster.Name), client.MatchingLabels(labels)); err != nil {
+		return fmt.Errorf("listing cluster role template bindings: %w", err)
+	}
+
+	desired := map[string]*managementv3.ClusterRoleTemplateBinding{}
+	for _, binding := range bindings {
+		desired[binding.name()] = binding.toClusterRoleTemplateBinding(rancherCluster, labels)
+	}
+
+	for _, crtb := range existing.Items {
+		if _, found := desired[crtb.Name]; found {
+			delete(desired, crtb.Name)
+			continue
+		}
+
+		log.Info("Removing cluster role template binding", "name", crtb.Name, "roleTemplate", crtb.RoleTemplateName)
+
+		if err := r.RancherClient.Delete(ctx, &crtb); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting cluster role template binding %s: %w", crtb.Name, err)
+		}
+	}
+
+	for _, crtb := range desired {
+		log.Info("Creating cluster role template binding", "name", crtb.Name, "roleTemplate", crtb.RoleTemplateName)
+
+		if err := r.RancherClient.Create(ctx, crtb); apierrors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("creating cluster role template binding %s: %w", crtb.Name, err)
+		}
+
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "ClusterRoleBindingCreated",
+			"Granted role template %s on Rancher cluster %s", crtb.RoleTemplateName, rancherCluster.Name)
+	}
+
+	return nil
+}
+
+// projectName returns a stable name for the project, so it is created only once in the Rancher cluster namespace.
+func projectName(rancherCluster *managementv3.Cluster, displayName string) string {
+	sum := sha256.Sum256([]byte(rancherCluster.Name + "/" + displayName))
+
+	return fmt.Sprintf("p-turtles-%x", sum[:5])
+}
+
+// reconcileProjects creates the requested projects. Projects no longer requested are released instead of deleted,
+// as they may hold namespaces and workloads: Turtles stops managing them and keeps them in Rancher.
+func (r *CAPIImportReconciler) reconcileProjects(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherCluster *managementv3.Cluster, projects []string,
+) error {
+	log := log.FromContext(ctx)
+	labels := accessOwnerLabels(capiCluster)
+
+	existing := &managementv3.ProjectList{}
+	if err := r.RancherClient.List(ctx, existing, client.InNamespace(rancherCluster.Name), client.MatchingLabels(labels)); err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	for _, project := range existing.Items {
+		if i := slices.Index(projects, project.Spec.DisplayName); i >= 0 {
+			projects = slices.Delete(projects, i, i+1)
+			continue
+		}
+
+		log.Info("Releasing project", "name", project.Name, "displayName", project.Spec.DisplayName)
+
+		patchBase := client.MergeFrom(project.DeepCopy())
+
+		for label := range labels {
+			delete(project.Labels, label)
+		}
+
+		if err := r.RancherClient.Patch(ctx, &project, patchBase); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("releasing project %s: %w", project.Name, err)
+		}
+
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "ProjectReleased",
+			"Project %s on Rancher cluster %s is kept and no longer managed by Turtles", project.Spec.DisplayName, rancherCluster.Name)
+	}
+
+	for _, displayName := range projects {
+		project := &managementv3.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      projectName(rancherCluster, displayName),
+				Namespace: rancherCluster.Name,
+				Labels:    labels,
+			},
+			Spec: managementv3.ProjectSpec{
+				ClusterName: rancherCluster.Name,
+				DisplayName: displayName,
+			},
+		}
+
+		log.Info("Creating project", "name", project.Name, "displayName", displayName)
+
+		err := r.RancherClient.Create(ctx, project)
+		if apierrors.IsAlreadyExists(err) {
+			// The project is either not observed yet, or was released and is requested again.
+			if err := r.adoptProject(ctx, project); err != nil {
+				return err
+			}
+
+			continue
+		} else if err != nil {
+			return fmt.Errorf("creating project %s: %w", displayName, err)
+		}
+
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "ProjectCreated",
+			"Created project %s on Rancher cluster %s", displayName, rancherCluster.Name)
+	}
+
+	return nil
+}
+
+// adoptProject adds the owner labels of the desired project to the existing project with the same name.
+func (r *CAPIImportReconciler) adoptProject(ctx context.Context, desired *managementv3.Project) error {
+	project := &managementv3.Project{}
+	if err := r.RancherClient.Get(ctx, client.ObjectKeyFromObject(desired), project); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patchBase := client.MergeFrom(project.DeepCopy())
+
+	if project.Labels == nil {
+		project.Labels = map[string]string{}
+	}
+
+	maps.Copy(project.Labels, desired.Labels)
+
+	if err := r.RancherClient.Patch(ctx, project, patchBase); err != nil {
+		return fmt.Errorf("adopting project %s: %w", project.Name, err)
+	}
+
+	return nil
+}
+
+// deleteClusterAccess removes the Rancher projects and cluster role template bindings created for the CAPI cluster.
+func (r *CAPIImportReconciler) deleteClusterAccess(ctx context.Context, capiCluster *clusterv1.Cluster) error {
+	labels := client.MatchingLabels(accessOwnerLabels(capiCluster))
+	errs := []error{}
+
+	crtbs := &managementv3.ClusterRoleTemplateBindingList{}
+	if err := r.RancherClient.List(ctx, crtbs, labels); err != nil {
+		return fmt.Errorf("listing cluster role template bindings: %w", err)
+	}
+
+	for _, crtb := range crtbs.Items {
+		if err := r.RancherClient.Delete(ctx, &crtb); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("deleting cluster role template binding %s: %w", crtb.Name, err))
+		}
+	}
+
+	projects := &managementv3.ProjectList{}
+	if err := r.RancherClient.List(ctx, projects, labels); err != nil {
+		return kerrors.NewAggregate(append(errs, fmt.Errorf("listing projects: %w", err)))
+	}
+
+	for _, project := range projects.Items {
+		if err := r.RancherClient.Delete(ctx, &project); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("deleting project %s: %w", project.Name, err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
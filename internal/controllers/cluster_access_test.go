@@ -0,0 +1,226 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Rancher cluster access", func() {
+	var (
+		r              *CAPIImportReconciler
+		fakeClient     client.Client
+		recorder       *record.FakeRecorder
+		namespace      *corev1.Namespace
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+	)
+
+	BeforeEach(func() {
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster",
+				Namespace:   namespace.Name,
+				Annotations: map[string]string{},
+			},
+		}
+		rancherCluster = &managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c-abcde"}}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(namespace).Build()
+		r = &CAPIImportReconciler{Client: fakeClient, RancherClient: fakeClient, recorder: recorder}
+	})
+
+	listBindings := func() []managementv3.ClusterRoleTemplateBinding {
+		crtbs := &managementv3.ClusterRoleTemplateBindingList{}
+		Expect(fakeClient.List(ctx, crtbs, client.InNamespace(rancherCluster.Name))).To(Succeed())
+
+		return crtbs.Items
+	}
+
+	listProjects := func() []string {
+		projects := &managementv3.ProjectList{}
+		Expect(fakeClient.List(ctx, projects, client.InNamespace(rancherCluster.Name))).To(Succeed())
+
+		names := []string{}
+		for _, project := range projects.Items {
+			Expect(project.Spec.ClusterName).To(Equal(rancherCluster.Name))
+			names = append(names, project.Spec.DisplayName)
+		}
+
+		return names
+	}
+
+	It("should parse cluster role bindings", func() {
+		bindings, err := parseClusterRoleBindings(" cluster-owner=user:u-abc, cluster-member=group:github_team://1234 ,")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bindings).To(ConsistOf(
+			clusterRoleBinding{RoleTemplateName: "cluster-owner", SubjectKind: subjectUser, Subject: "u-abc"},
+			clusterRoleBinding{RoleTemplateName: "cluster-member", SubjectKind: subjectGroup, Subject: "github_team://1234"},
+		))
+
+		_, err = parseClusterRoleBindings("cluster-owner=u-abc")
+		Expect(err).To(HaveOccurred())
+
+		_, err = parseClusterRoleBindings("cluster-owner=team:abc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should create and remove bindings and projects requested on the cluster", func() {
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner=user:u-abc,cluster-member=userPrincipal:local://u-def"
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = "team-a, team-b"
+
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		crtbs := listBindings()
+		Expect(crtbs).To(HaveLen(2))
+		for _, crtb := range crtbs {
+			Expect(crtb.ClusterName).To(Equal(rancherCluster.Name))
+			Expect(crtb.Labels).To(HaveKeyWithValue(capiClusterOwner, capiCluster.Name))
+			Expect(crtb.Labels).To(HaveKey(ownedLabelName))
+
+			switch crtb.RoleTemplateName {
+			case "cluster-owner":
+				Expect(crtb.UserName).To(Equal("u-abc"))
+			case "cluster-member":
+				Expect(crtb.UserPrincipalName).To(Equal("local://u-def"))
+			default:
+				Fail("unexpected role template " + crtb.RoleTemplateName)
+			}
+		}
+		Expect(listProjects()).To(ConsistOf("team-a", "team-b"))
+
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner=user:u-abc"
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = "team-b,team-c"
+
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(listBindings()).To(HaveLen(1))
+		Expect(listProjects()).To(ConsistOf("team-a", "team-b", "team-c"))
+
+		released := &managementv3.Project{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{
+			Namespace: rancherCluster.Name,
+			Name:      projectName(rancherCluster, "team-a"),
+		}, released)).To(Succeed())
+		Expect(released.Labels).ToNot(HaveKey(capiClusterOwner))
+		Expect(released.Labels).ToNot(HaveKey(ownedLabelName))
+	})
+
+	It("should manage a released project again when it is requested again", func() {
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = "team-a"
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = ""
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = "team-a"
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		projects := &managementv3.ProjectList{}
+		Expect(fakeClient.List(ctx, projects, client.MatchingLabels(accessOwnerLabels(capiCluster)))).To(Succeed())
+		Expect(projects.Items).To(HaveLen(1))
+		Expect(projects.Items[0].Spec.DisplayName).To(Equal("team-a"))
+	})
+
+	It("should not report bindings created by a previous reconcile as created", func() {
+		binding := clusterRoleBinding{RoleTemplateName: "cluster-owner", SubjectKind: subjectUser, Subject: "u-abc"}
+		Expect(fakeClient.Create(ctx, binding.toClusterRoleTemplateBinding(rancherCluster, nil))).To(Succeed())
+
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner=user:u-abc"
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should leave objects not created by turtles untouched", func() {
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		manual := &managementv3.ClusterRoleTemplateBinding{
+			ObjectMeta:       metav1.ObjectMeta{Name: "crtb-manual", Namespace: rancherCluster.Name},
+			ClusterName:      rancherCluster.Name,
+			RoleTemplateName: "cluster-owner",
+			UserName:         "u-xyz",
+		}
+		Expect(fakeClient.Create(ctx, manual)).To(Succeed())
+
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(r.deleteClusterAccess(ctx, capiCluster)).To(Succeed())
+		Expect(listBindings()).To(HaveLen(1))
+	})
+
+	Context("with the namespace annotated", func() {
+		BeforeEach(func() {
+			namespace.Annotations = map[string]string{
+				turtlesannotations.ClusterRoleBindingsAnnotation: "cluster-owner=group:local://g-admins",
+				turtlesannotations.ProjectsAnnotation:            "shared",
+			}
+		})
+
+		It("should fall back to the namespace annotations", func() {
+			Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+			crtbs := listBindings()
+			Expect(crtbs).To(HaveLen(1))
+			Expect(crtbs[0].GroupPrincipalName).To(Equal("local://g-admins"))
+			Expect(listProjects()).To(ConsistOf("shared"))
+		})
+
+		It("should prefer the cluster annotations", func() {
+			capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = ""
+
+			Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+			Expect(listBindings()).To(HaveLen(1))
+			Expect(listProjects()).To(BeEmpty())
+		})
+	})
+
+	It("should keep existing bindings when the annotation is invalid", func() {
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner=user:u-abc"
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner"
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(listBindings()).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal ClusterRoleBindingCreated")))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning InvalidClusterRoleBindings")))
+	})
+
+	It("should delete bindings and projects with the CAPI cluster", func() {
+		capiCluster.Annotations[turtlesannotations.ClusterRoleBindingsAnnotation] = "cluster-owner=user:u-abc"
+		capiCluster.Annotations[turtlesannotations.ProjectsAnnotation] = "team-a"
+
+		Expect(r.reconcileClusterAccess(ctx, capiCluster, rancherCluster)).To(Succeed())
+		Expect(r.deleteClusterAccess(ctx, capiCluster)).To(Succeed())
+
+		Expect(listBindings()).To(BeEmpty())
+		Expect(listProjects()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+)
+
+const (
+	// rancherBindingSuffix is appended to the CAPI cluster name to name the Secret binding it to its Rancher cluster.
+	// The Secret is owned by the CAPI cluster and prefixed with its name, so clusterctl move moves it with the cluster.
+	rancherBindingSuffix = "-rancher-binding"
+	// rancherBindingClusterKey is the key of the Rancher cluster name in the binding Secret.
+	rancherBindingClusterKey = "cluster"
+	// rancherBindingTokenKey is the key of the Rancher cluster registration token in the binding Secret.
+	rancherBindingTokenKey = "token"
+)
+
+// reconcileRancherBinding records the Rancher cluster name and registration token in the binding Secret of the CAPI cluster.
+// The binding is skipped until Rancher generates the registration token.
+func (r *CAPIImportReconciler) reconcileRancherBinding(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherCluster *managementv3.Cluster,
+) error {
+	token := &managementv3.ClusterRegistrationToken{}
+	if err := r.RancherClient.Get(ctx, client.ObjectKey{Namespace: rancherCluster.Name, Name: rancherCluster.Name}, token); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if token.Status.Token == "" {
+		return nil
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      capiCluster.Name + rancherBindingSuffix,
+		Namespace: capiCluster.Namespace,
+	}}
+	if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(capiCluster, clusterv1.GroupVersion.WithKind("Cluster"))}
+		secret.Data = map[string][]byte{
+			rancherBindingClusterKey: []byte(rancherCluster.Name),
+			rancherBindingTokenKey:   []byte(token.Status.Token),
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reconciling rancher binding secret %s: %w", secret.Name, err)
+	} else if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).V(4).Info("Rancher binding secret reconciled", "secret", secret.Name, "operation", op)
+	}
+
+	return nil
+}
+
+// adoptMovedRancherCluster adopts the Rancher cluster recorded in the binding Secret of a CAPI cluster moved
+// from another management cluster, and adds it to the Rancher clusters of the CAPI cluster. The Rancher cluster
+// is only adopted when it is not owned by another CAPI cluster and its registration token matches the binding.
+func (r *CAPIImportReconciler) adoptMovedRancherCluster(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherClusters *managementv3.ClusterList,
+) error {
+	log := log.FromContext(ctx)
+
+	binding := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: capiCluster.Namespace,
+		Name:      capiCluster.Name + rancherBindingSuffix,
+	}, binding); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	name := string(binding.Data[rancherBindingClusterKey])
+	if name == "" {
+		return nil
+	}
+
+	rancherCluster := &managementv3.Cluster{}
+	if err := r.RancherClient.Get(ctx, client.ObjectKey{Namespace: capiCluster.Namespace, Name: name}, rancherCluster); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Rancher cluster of the binding no longer exists, not adopting it", "rancherCluster", name)
+		}
+
+		return client.IgnoreNotFound(err)
+	}
+
+	if !rancherCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	owner, found := rancherCluster.Labels[capiClusterOwner]
+	if found && (owner != capiCluster.Name || rancherCluster.Labels[capiClusterOwnerNamespace] != capiCluster.Namespace) {
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "RancherClusterBindingMismatch",
+			"Rancher cluster %s of the binding is owned by CAPI cluster %s/%s, not adopting it",
+			name, rancherCluster.Labels[capiClusterOwnerNamespace], owner)
+
+		return nil
+	}
+
+	token := &managementv3.ClusterRegistrationToken{}
+	if err := r.RancherClient.Get(ctx, client.ObjectKey{Namespace: name, Name: name}, token); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("error getting registration token of rancher cluster %s: %w", name, err)
+	}
+
+	if token.Status.Token == "" || token.Status.Token != string(binding.Data[rancherBindingTokenKey]) {
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "RancherClusterBindingMismatch",
+			"Registration token of Rancher cluster %s doesn't match the binding, not adopting it", name)
+
+		return nil
+	}
+
+	patchBase := client.MergeFromWithOptions(rancherCluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	labels := rancherCluster.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[capiClusterOwner] = capiCluster.Name
+	labels[capiClusterOwnerNamespace] = capiCluster.Namespace
+	labels[ownedLabelName] = ""
+	rancherCluster.SetLabels(labels)
+	controllerutil.AddFinalizer(rancherCluster, managementv3.CapiClusterFinalizer)
+
+	if err := r.RancherClient.Patch(ctx, rancherCluster, patchBase); err != nil {
+		return fmt.Errorf("error adopting rancher cluster %s: %w", name, err)
+	}
+
+	log.Info("Adopted Rancher cluster of the moved CAPI cluster", "rancherCluster", name)
+	r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "RancherClusterAdopted",
+		"Rancher cluster %s is adopted after the cluster was moved", name)
+
+	rancherClusters.Items = append(rancherClusters.Items, *rancherCluster)
+
+	return nil
+}
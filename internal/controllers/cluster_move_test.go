@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+)
+
+var _ = Describe("clusterctl move", func() {
+	var (
+		r              *CAPIImportReconciler
+		fakeClient     client.Client
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+		token          *managementv3.ClusterRegistrationToken
+		objects        []client.Object
+	)
+
+	bindingFor := func(name, token string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: capiCluster.Name + rancherBindingSuffix, Namespace: capiCluster.Namespace},
+			Data: map[string][]byte{
+				rancherBindingClusterKey: []byte(name),
+				rancherBindingTokenKey:   []byte(token),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cluster",
+				Namespace:  "ns",
+				Finalizers: []string{managementv3.CapiClusterFinalizer},
+			},
+		}
+		rancherCluster = &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "c-abcde",
+				Namespace:  capiCluster.Namespace,
+				Finalizers: []string{managementv3.CapiClusterFinalizer},
+			},
+		}
+		token = &managementv3.ClusterRegistrationToken{
+			ObjectMeta: metav1.ObjectMeta{Name: rancherCluster.Name, Namespace: rancherCluster.Name},
+			Spec:       managementv3.ClusterRegistrationTokenSpec{ClusterName: rancherCluster.Name},
+			Status:     managementv3.ClusterRegistrationTokenStatus{Token: "secret"},
+		}
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{importLabelName: "true"}}},
+			&managementv3.Setting{ObjectMeta: metav1.ObjectMeta{Name: "agent-tls-mode"}, Value: "system-store"},
+			token,
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().
+			WithObjects(append(objects, capiCluster, rancherCluster)...).
+			WithStatusSubresource(&managementv3.Cluster{}, &managementv3.ClusterRegistrationToken{}).
+			Build()
+		r = &CAPIImportReconciler{
+			Client:         fakeClient,
+			UncachedClient: fakeClient,
+			RancherClient:  fakeClient,
+			recorder:       record.NewFakeRecorder(10),
+		}
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+	})
+
+	Context("on the source management cluster", func() {
+		BeforeEach(func() {
+			rancherCluster.Labels = map[string]string{
+				capiClusterOwner:          capiCluster.Name,
+				capiClusterOwnerNamespace: capiCluster.Namespace,
+				ownedLabelName:            "",
+			}
+		})
+
+		It("should keep the Rancher cluster when the CAPI cluster is moved", func() {
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(capiCluster)}
+
+			// clusterctl pauses the cluster, then marks it for deletion, removes its finalizers and deletes it.
+			capiCluster.Spec.Paused = ptr.To(true)
+			Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			capiCluster.Annotations = map[string]string{clusterctlv1.DeleteForMoveAnnotation: ""}
+			Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+			Expect(capiCluster.Finalizers).To(ContainElement(managementv3.CapiClusterFinalizer))
+
+			capiCluster.Finalizers = nil
+			Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+			Expect(fakeClient.Delete(ctx, capiCluster)).To(Succeed())
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+			Expect(rancherCluster.DeletionTimestamp.IsZero()).To(BeTrue())
+			Expect(ownsRancherCluster(capiCluster, rancherCluster)).To(BeTrue())
+		})
+	})
+
+	Context("with an imported cluster", func() {
+		BeforeEach(func() {
+			rancherCluster.Labels = map[string]string{
+				capiClusterOwner:          capiCluster.Name,
+				capiClusterOwnerNamespace: capiCluster.Namespace,
+				ownedLabelName:            "",
+			}
+		})
+
+		It("should record the Rancher cluster binding", func() {
+			Expect(r.reconcileRancherBinding(ctx, capiCluster, rancherCluster)).To(Succeed())
+
+			binding := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bindingFor("", "")), binding)).To(Succeed())
+			Expect(binding.Data).To(Equal(bindingFor(rancherCluster.Name, "secret").Data))
+			Expect(binding.OwnerReferences).To(HaveLen(1))
+			Expect(binding.OwnerReferences[0].Name).To(Equal(capiCluster.Name))
+		})
+	})
+
+	Context("on the target management cluster", func() {
+		It("should adopt the Rancher cluster of the binding", func() {
+			Expect(fakeClient.Create(ctx, bindingFor(rancherCluster.Name, "secret"))).To(Succeed())
+
+			rancherClusters := &managementv3.ClusterList{}
+			Expect(r.adoptMovedRancherCluster(ctx, capiCluster, rancherClusters)).To(Succeed())
+			Expect(rancherClusters.Items).To(HaveLen(1))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+			Expect(ownsRancherCluster(capiCluster, rancherCluster)).To(BeTrue())
+		})
+
+		It("should not adopt the Rancher cluster when the registration token doesn't match", func() {
+			Expect(fakeClient.Create(ctx, bindingFor(rancherCluster.Name, "other"))).To(Succeed())
+
+			rancherClusters := &managementv3.ClusterList{}
+			Expect(r.adoptMovedRancherCluster(ctx, capiCluster, rancherClusters)).To(Succeed())
+			Expect(rancherClusters.Items).To(BeEmpty())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+			Expect(ownsRancherCluster(capiCluster, rancherCluster)).To(BeFalse())
+		})
+
+		Context("when the Rancher cluster is owned by another CAPI cluster", func() {
+			BeforeEach(func() {
+				rancherCluster.Labels = map[string]string{
+					capiClusterOwner:          "other",
+					capiClusterOwnerNamespace: capiCluster.Namespace,
+					ownedLabelName:            "",
+				}
+			})
+
+			It("should not adopt it", func() {
+				Expect(fakeClient.Create(ctx, bindingFor(rancherCluster.Name, "secret"))).To(Succeed())
+
+				rancherClusters := &managementv3.ClusterList{}
+				Expect(r.adoptMovedRancherCluster(ctx, capiCluster, rancherClusters)).To(Succeed())
+				Expect(rancherClusters.Items).To(BeEmpty())
+			})
+		})
+
+		It("should use the adopted Rancher cluster instead of creating a new one", func() {
+			Expect(fakeClient.Create(ctx, bindingFor(rancherCluster.Name, "secret"))).To(Succeed())
+
+			_, err := r.reconcile(ctx, capiCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			rancherClusters := &managementv3.ClusterList{}
+			Expect(fakeClient.List(ctx, rancherClusters)).To(Succeed())
+			Expect(rancherClusters.Items).To(HaveLen(1))
+			Expect(rancherClusters.Items[0].Name).To(Equal(rancherCluster.Name))
+		})
+	})
+})
@@ -76,10 +76,10 @@ func Config() *corev1.ConfigMap {
 
 // SyncConfigMap updates the Clusterctl ConfigMap with the user-specified
 // overrides from ClusterctlConfig.
-func SyncConfigMap(ctx context.Context, c client.Client, owner string) error {
+func SyncConfigMap(ctx context.Context, c, rancherClient client.Client, owner string) error {
 	configMap := Config()
 
-	clusterctlConfig, err := ClusterConfig(ctx, c)
+	clusterctlConfig, err := ClusterConfig(ctx, c, rancherClient)
 	if err != nil {
 		return fmt.Errorf("getting updated ClusterctlConfig: %w", err)
 	}
@@ -103,7 +103,8 @@ func SyncConfigMap(ctx context.Context, c client.Client, owner string) error {
 
 // ClusterConfig collects overrides config from the local in-memory state
 // and the user-specified ClusterctlConfig overrides layer.
-func ClusterConfig(ctx context.Context, c client.Client) (*ConfigRepository, error) {
+// Rancher settings are read with the rancherClient, which may point to a separate Rancher Manager cluster.
+func ClusterConfig(ctx context.Context, c, rancherClient client.Client) (*ConfigRepository, error) {
 	log := log.FromContext(ctx)
 
 	configMap := Config()
@@ -152,7 +153,7 @@ func ClusterConfig(ctx context.Context, c client.Client) (*ConfigRepository, err
 		log.Info("Turtles configured to use Rancher default registry for images")
 
 		setting := &managementv3.Setting{}
-		if err := c.Get(ctx, client.ObjectKey{Name: "system-default-registry"}, setting); err != nil {
+		if err := rancherClient.Get(ctx, client.ObjectKey{Name: "system-default-registry"}, setting); err != nil {
 			log.Error(err, "Unable to get system-default-registry setting")
 			return nil, err
 		}
@@ -126,7 +126,7 @@ data:
 	})
 
 	It("should leave unchanged, deduplicate and add new providers correctly", func() {
-		configRepo, err := ClusterConfig(ctx, fakeClient)
+		configRepo, err := ClusterConfig(ctx, fakeClient, fakeClient)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(configRepo.Providers).To(HaveLen(5))
@@ -166,6 +166,28 @@ data:
 			URL:  "https://github.com/rancher/cluster-api-addon-provider-fleet/releases/v0.14.1/addon-components.yaml",
 		}))
 	})
+
+	It("should read the system-default-registry setting from the Rancher client", func() {
+		rancherClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				&managementv3.Setting{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "system-default-registry",
+					},
+					Value: "rancher.example.com",
+				},
+			).
+			Build()
+
+		configRepo, err := ClusterConfig(ctx, fakeClient, rancherClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(configRepo.Images).To(HaveKeyWithValue("image1", ConfigImage{
+			Repository: "rancher.example.com/repo1",
+			Tag:        "v1",
+		}))
+	})
 })
 
 func TestClusterctl(t *testing.T) {
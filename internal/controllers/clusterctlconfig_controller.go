@@ -35,6 +35,8 @@ import (
 // ClusterctlConfigReconciler reconciles a ClusterctlConfig object.
 type ClusterctlConfigReconciler struct {
 	client.Client
+
+	RancherClient client.Client
 }
 
 // Config is a direct clusterctl config representation.
@@ -62,6 +64,10 @@ func configMapMapper(_ context.Context, obj client.Object) []reconcile.Request {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterctlConfigReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, _ controller.Options) error {
+	if r.RancherClient == nil {
+		r.RancherClient = r.Client
+	}
+
 	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&turtlesv1.ClusterctlConfig{}).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(configMapMapper)).
@@ -82,7 +88,7 @@ func (r *ClusterctlConfigReconciler) SetupWithManager(_ context.Context, mgr ctr
 func (r *ClusterctlConfigReconciler) Reconcile(ctx context.Context, _ reconcile.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
-	if err := clusterctl.SyncConfigMap(ctx, r.Client, "clusterctlconfig-controller"); err != nil {
+	if err := clusterctl.SyncConfigMap(ctx, r.Client, r.RancherClient, "clusterctlconfig-controller"); err != nil {
 		log.Error(err, "Unable to sync clusterctl ConfigMap")
 		return ctrl.Result{}, err
 	}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+// rancherClusterName returns the name of the Rancher cluster created for the CAPI cluster. The name is derived
+// from the CAPI cluster, so creating the Rancher cluster again from a stale cache fails instead of creating a duplicate.
+func rancherClusterName(capiCluster *clusterv1.Cluster) string {
+	sum := sha256.Sum256([]byte(capiCluster.Namespace + "/" + capiCluster.Name))
+
+	return fmt.Sprintf("c-%x", sum[:5])
+}
+
+// ownsRancherCluster returns true when the Rancher cluster carries the ownership labels of the CAPI cluster.
+func ownsRancherCluster(capiCluster *clusterv1.Cluster, rancherCluster *managementv3.Cluster) bool {
+	labels := rancherCluster.GetLabels()
+	_, owned := labels[ownedLabelName]
+
+	return owned && labels[capiClusterOwner] == capiCluster.Name && labels[capiClusterOwnerNamespace] == capiCluster.Namespace
+}
+
+// preferred orders a before b when only a has the preferred property.
+func preferred(a, b bool) int {
+	switch {
+	case a && !b:
+		return -1
+	case b && !a:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// canonicalRancherCluster sorts the Rancher clusters found for the CAPI cluster and returns the one it is imported as:
+// the one the agent is connected to, then the one not being deleted, then the one with the derived name, then the oldest.
+func canonicalRancherCluster(capiCluster *clusterv1.Cluster, rancherClusters []managementv3.Cluster) *managementv3.Cluster {
+	name := rancherClusterName(capiCluster)
+
+	slices.SortStableFunc(rancherClusters, func(a, b managementv3.Cluster) int {
+		return cmp.Or(
+			preferred(conditions.IsTrue(&a, managementv3.ClusterConditionReady), conditions.IsTrue(&b, managementv3.ClusterConditionReady)),
+			preferred(a.DeletionTimestamp.IsZero(), b.DeletionTimestamp.IsZero()),
+			preferred(a.Name == name, b.Name == name),
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+
+	return &rancherClusters[0]
+}
+
+// removeDuplicateRancherClusters releases the Rancher clusters found for the CAPI cluster other than the canonical one.
+// The duplicates are deleted with the Cascade deletion policy, and kept in Rancher otherwise.
+func (r *CAPIImportReconciler) removeDuplicateRancherClusters(ctx context.Context, capiCluster *clusterv1.Cluster,
+	canonical *managementv3.Cluster, rancherClusters []managementv3.Cluster,
+) error {
+	log := log.FromContext(ctx)
+
+	deletionPolicy, err := r.deletionPolicy(ctx, capiCluster)
+	if err != nil {
+		return err
+	}
+
+	duplicates := []string{}
+
+	for i := range rancherClusters {
+		duplicate := &rancherClusters[i]
+		if duplicate.Name == canonical.Name {
+			continue
+		}
+
+		log.Info("Removing duplicate rancher cluster", "rancherCluster", duplicate.Name, "canonical", canonical.Name)
+
+		// Releasing the duplicate first keeps its deletion from being handled as the deletion of the imported cluster.
+		if err := r.releaseRancherCluster(ctx, capiCluster, duplicate); err != nil {
+			return fmt.Errorf("releasing duplicate rancher cluster %s: %w", duplicate.Name, err)
+		}
+
+		if deletionPolicy == turtlesv1.DeletionPolicyCascade {
+			if err := r.RancherClient.Delete(ctx, duplicate); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting duplicate rancher cluster %s: %w", duplicate.Name, err)
+			}
+		}
+
+		duplicates = append(duplicates, duplicate.Name)
+	}
+
+	action := "Released"
+	if deletionPolicy == turtlesv1.DeletionPolicyCascade {
+		action = "Deleted"
+	}
+
+	message := fmt.Sprintf("%s duplicate Rancher clusters %s, the cluster is imported as %s",
+		action, strings.Join(duplicates, ", "), canonical.Name)
+
+	r.recorder.Event(capiCluster, corev1.EventTypeWarning, "DuplicateRancherClusters", message)
+
+	conditions.Set(capiCluster, metav1.Condition{
+		Type:    turtlesv1.RancherClusterUniqueCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  turtlesv1.RancherClusterDuplicatesRemovedReason,
+		Message: message,
+	})
+
+	return nil
+}
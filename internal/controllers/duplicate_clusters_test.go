@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Duplicate Rancher clusters", func() {
+	var (
+		r           *CAPIImportReconciler
+		fakeClient  client.Client
+		capiCluster *clusterv1.Cluster
+		connected   *managementv3.Cluster
+		duplicate   *managementv3.Cluster
+		objects     []client.Object
+	)
+
+	rancherClusterFor := func(name string, created time.Time) *managementv3.Cluster {
+		return &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					capiClusterOwner:          capiCluster.Name,
+					capiClusterOwnerNamespace: capiCluster.Namespace,
+					ownedLabelName:            "",
+				},
+				Finalizers: []string{managementv3.CapiClusterFinalizer},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+			},
+		}
+
+		now := time.Now().Truncate(time.Second)
+		duplicate = rancherClusterFor("c-aaaaa", now.Add(-time.Hour))
+		connected = rancherClusterFor("c-bbbbb", now)
+		conditions.Set(connected, metav1.Condition{
+			Type:   managementv3.ClusterConditionReady,
+			Status: metav1.ConditionTrue,
+			Reason: "Connected",
+		})
+
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{importLabelName: "true"}}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().
+			WithObjects(append(objects, capiCluster)...).
+			WithStatusSubresource(&managementv3.Cluster{}).
+			Build()
+		r = &CAPIImportReconciler{
+			Client:         fakeClient,
+			UncachedClient: fakeClient,
+			RancherClient:  fakeClient,
+			recorder:       record.NewFakeRecorder(10),
+		}
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+	})
+
+	createRancherClusters := func(rancherClusters ...*managementv3.Cluster) {
+		for _, rancherCluster := range rancherClusters {
+			status := rancherCluster.Status
+			Expect(fakeClient.Create(ctx, rancherCluster)).To(Succeed())
+
+			rancherCluster.Status = status
+			Expect(fakeClient.Status().Update(ctx, rancherCluster)).To(Succeed())
+		}
+	}
+
+	It("should derive the Rancher cluster name from the CAPI cluster", func() {
+		name := rancherClusterName(capiCluster)
+		Expect(name).To(MatchRegexp(`^c-[0-9a-f]{10}$`))
+
+		other := capiCluster.DeepCopy()
+		other.Namespace = "other"
+		Expect(rancherClusterName(other)).ToNot(Equal(name))
+	})
+
+	It("should prefer the Rancher cluster the agent is connected to", func() {
+		derived := rancherClusterFor(rancherClusterName(capiCluster), time.Now())
+		rancherClusters := []managementv3.Cluster{*duplicate, *derived, *connected}
+
+		Expect(canonicalRancherCluster(capiCluster, rancherClusters).Name).To(Equal(connected.Name))
+		Expect(canonicalRancherCluster(capiCluster, rancherClusters[1:]).Name).To(Equal(derived.Name))
+		Expect(canonicalRancherCluster(capiCluster, rancherClusters[1:2]).Name).To(Equal(derived.Name))
+	})
+
+	It("should prefer the oldest Rancher cluster otherwise", func() {
+		rancherClusters := []managementv3.Cluster{*rancherClusterFor("c-ccccc", time.Now()), *duplicate}
+
+		Expect(canonicalRancherCluster(capiCluster, rancherClusters).Name).To(Equal(duplicate.Name))
+	})
+
+	It("should delete the duplicates on Cascade", func() {
+		createRancherClusters(duplicate, connected)
+
+		res, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(defaultRequeueDuration))
+
+		rancherClusters := &managementv3.ClusterList{}
+		Expect(fakeClient.List(ctx, rancherClusters)).To(Succeed())
+		Expect(rancherClusters.Items).To(HaveLen(1))
+		Expect(rancherClusters.Items[0].Name).To(Equal(connected.Name))
+
+		condition := conditions.Get(capiCluster, turtlesv1.RancherClusterUniqueCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(turtlesv1.RancherClusterDuplicatesRemovedReason))
+		Expect(condition.Message).To(ContainSubstring(duplicate.Name))
+	})
+
+	Context("on Orphan", func() {
+		BeforeEach(func() {
+			capiCluster.Annotations = map[string]string{turtlesannotations.DeletionPolicyAnnotation: string(turtlesv1.DeletionPolicyOrphan)}
+		})
+
+		It("should release the duplicates", func() {
+			createRancherClusters(duplicate, connected)
+
+			_, err := r.reconcile(ctx, capiCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(duplicate), duplicate)).To(Succeed())
+			Expect(duplicate.DeletionTimestamp.IsZero()).To(BeTrue())
+			Expect(ownsRancherCluster(capiCluster, duplicate)).To(BeFalse())
+			Expect(duplicate.Finalizers).To(BeEmpty())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(connected), connected)).To(Succeed())
+			Expect(ownsRancherCluster(capiCluster, connected)).To(BeTrue())
+		})
+	})
+
+	It("should create the Rancher cluster with the derived name", func() {
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		rancherCluster := &managementv3.Cluster{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: capiCluster.Namespace, Name: rancherClusterName(capiCluster)}, rancherCluster)).To(Succeed())
+		Expect(ownsRancherCluster(capiCluster, rancherCluster)).To(BeTrue())
+	})
+
+	It("should generate a name when the derived name is taken by a released Rancher cluster", func() {
+		released := &managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+			Namespace: capiCluster.Namespace,
+			Name:      rancherClusterName(capiCluster),
+		}}
+		Expect(fakeClient.Create(ctx, released)).To(Succeed())
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		rancherClusters := &managementv3.ClusterList{}
+		Expect(fakeClient.List(ctx, rancherClusters, client.MatchingLabels{capiClusterOwner: capiCluster.Name})).To(Succeed())
+		Expect(rancherClusters.Items).To(HaveLen(1))
+		Expect(rancherClusters.Items[0].Name).To(HavePrefix("c-"))
+		Expect(rancherClusters.Items[0].Name).ToNot(Equal(released.Name))
+	})
+})
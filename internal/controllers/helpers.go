@@ -19,12 +19,9 @@ package controllers
 import (
 	"bufio"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -43,7 +40,9 @@ import (
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
 	"github.com/rancher/turtles/util"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
 )
 
 const (
@@ -53,13 +52,18 @@ const (
 	capiClusterOwnerNamespace = "cluster-api.cattle.io/capi-cluster-owner-ns"
 	fleetNamespaceMigrated    = "cluster-api.cattle.io/fleet-namespace-migrated"
 	fleetDisabledLabel        = "cluster-api.cattle.io/disable-fleet-auto-import"
+	fleetWorkspaceLabel       = "cluster-api.cattle.io/fleet-workspace"
+	defaultFleetWorkspace     = "fleet-default"
 
 	defaultRequeueDuration = 1 * time.Minute
 	trueValue              = "true"
+
+	// importFieldOwner is the field manager used when server-side applying the import manifest.
+	importFieldOwner = "rancher-turtles-import"
 )
 
 func getClusterRegistrationManifest(ctx context.Context, clusterName, namespace string, cl client.Client,
-	caCert []byte, insecureSkipVerify bool,
+	downloader *manifestDownloader,
 ) (string, error) {
 	log := log.FromContext(ctx)
 
@@ -82,11 +86,12 @@ func getClusterRegistrationManifest(ctx context.Context, clusterName, namespace
 		}
 	}
 
-	if token.Status.ManifestURL == "" {
-		return "", nil
+	manifestURL, err := downloader.manifestURL(token)
+	if err != nil || manifestURL == "" {
+		return "", err
 	}
 
-	manifestData, err := downloadManifest(token.Status.ManifestURL, caCert, insecureSkipVerify)
+	manifestData, err := downloader.download(ctx, manifestURL)
 	if err != nil {
 		log.Error(err, "failed downloading import manifest")
 		return "", err
@@ -95,6 +100,55 @@ func getClusterRegistrationManifest(ctx context.Context, clusterName, namespace
 	return manifestData, nil
 }
 
+// regenerateRegistrationToken replaces the cluster registration token with a new one created for the requested re-import,
+// so Rancher generates a new registration manifest. It returns true while the previous token is being removed.
+func regenerateRegistrationToken(ctx context.Context, cl client.Client, clusterName, namespace, reimport string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	token := &managementv3.ClusterRegistrationToken{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+
+	err := cl.Get(ctx, client.ObjectKeyFromObject(token), token)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("error getting registration token for cluster %s: %w", clusterName, err)
+	} else if err == nil {
+		if token.GetAnnotations()[turtlesannotations.ReimportAnnotation] == reimport {
+			return false, nil
+		}
+
+		log.Info("Removing cluster registration token for re-import", "token", client.ObjectKeyFromObject(token))
+
+		if err := cl.Delete(ctx, token); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete cluster registration token for cluster %s: %w", clusterName, err)
+		}
+
+		return true, nil
+	}
+
+	token = &managementv3.ClusterRegistrationToken{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				turtlesannotations.ReimportAnnotation: reimport,
+			},
+		},
+		Spec: managementv3.ClusterRegistrationTokenSpec{
+			ClusterName: clusterName,
+		},
+	}
+
+	if err := cl.Create(ctx, token); err != nil {
+		return false, fmt.Errorf("failed to create cluster registration token for cluster %s: %w", clusterName, err)
+	}
+
+	return false, nil
+}
+
 func namespaceToCapiClusters(ctx context.Context, clusterPredicate predicate.Funcs, cl client.Client) handler.MapFunc {
 	log := log.FromContext(ctx)
 
@@ -140,37 +194,46 @@ func namespaceToCapiClusters(ctx context.Context, clusterPredicate predicate.Fun
 	}
 }
 
-func downloadManifest(url string, caCert []byte, insecureSkipVerify bool) (string, error) {
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
-	}
+// clusterImportPolicyToCapiClusters maps a ClusterImportPolicy to the CAPI clusters it selects.
+func clusterImportPolicyToCapiClusters(ctx context.Context, clusterPredicate predicate.Funcs, cl client.Client) handler.MapFunc {
+	log := log.FromContext(ctx)
+
+	return func(_ context.Context, o client.Object) []ctrl.Request {
+		policy, ok := o.(*turtlesv1.ClusterImportPolicy)
+		if !ok {
+			log.Error(nil, fmt.Sprintf("Expected a ClusterImportPolicy but got a %T", o))
+			return nil
+		}
 
-	// Only trust the CA certificate if it is provided
-	if caCert != nil { //
-		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM(caCert) {
-			return "", errors.New("failed to append CA certificate")
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.ClusterSelector)
+		if err != nil {
+			log.Error(err, "invalid cluster selector", "policy", policy.Name)
+			return nil
 		}
 
-		tlsConfig.RootCAs = caCertPool
-	}
+		capiClusters := &clusterv1.ClusterList{}
+		if err := cl.List(ctx, capiClusters, client.InNamespace(policy.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			log.Error(err, "getting capi clusters")
+			return nil
+		}
 
-	client := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: tlsConfig,
-	}}
+		reqs := []ctrl.Request{}
 
-	resp, err := client.Get(url) //nolint:gosec,noctx
-	if err != nil {
-		return "", fmt.Errorf("downloading manifest: %w", err)
-	}
-	defer resp.Body.Close()
+		for _, cluster := range capiClusters.Items {
+			if !clusterPredicate.Generic(event.GenericEvent{Object: &cluster}) {
+				continue
+			}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading manifest: %w", err)
-	}
+			reqs = append(reqs, ctrl.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: cluster.Namespace,
+					Name:      cluster.Name,
+				},
+			})
+		}
 
-	return string(data), err
+		return reqs
+	}
 }
 
 // removeFleetNamespace cleans up previous namespace of the deployed agent on the downstream cluster.
@@ -211,7 +274,7 @@ func removeFleetNamespace(ctx context.Context, cl client.Client, cluster *manage
 	return true, nil
 }
 
-func createImportManifest(ctx context.Context, remoteClient client.Client, in io.Reader) error {
+func createImportManifest(ctx context.Context, remoteClient client.Client, in io.Reader, serverSideApply bool) error {
 	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
 
 	for {
@@ -224,7 +287,7 @@ func createImportManifest(ctx context.Context, remoteClient client.Client, in io
 			return err
 		}
 
-		if err := createRawManifest(ctx, remoteClient, raw); err != nil {
+		if err := createRawManifest(ctx, remoteClient, raw, serverSideApply); err != nil {
 			return err
 		}
 	}
@@ -267,14 +330,20 @@ func validateImportReadiness(ctx context.Context, remoteClient client.Client, in
 	return false, nil
 }
 
-func createRawManifest(ctx context.Context, remoteClient client.Client, bytes []byte) error {
+func createRawManifest(ctx context.Context, remoteClient client.Client, bytes []byte, serverSideApply bool) error {
 	items, err := utilyaml.ToUnstructured(bytes)
 	if err != nil {
 		return fmt.Errorf("error unmarshalling bytes or empty object passed: %w", err)
 	}
 
 	for _, obj := range items {
-		if err := createObject(ctx, remoteClient, obj.DeepCopy()); err != nil {
+		if serverSideApply {
+			err = applyObject(ctx, remoteClient, obj.DeepCopy())
+		} else {
+			err = createObject(ctx, remoteClient, obj.DeepCopy())
+		}
+
+		if err != nil {
 			return err
 		}
 	}
@@ -336,6 +405,24 @@ func createObject(ctx context.Context, c client.Client, obj client.Object) error
 	return nil
 }
 
+// applyObject server-side applies the object, taking over the fields set in the import manifest
+// and reverting any drift on the remote cluster.
+func applyObject(ctx context.Context, c client.Client, obj client.Object) error {
+	log := log.FromContext(ctx)
+	gvk := obj.GetObjectKind().GroupVersionKind()
+
+	if err := c.Patch(ctx, obj, client.Apply, []client.PatchOption{
+		client.ForceOwnership,
+		client.FieldOwner(importFieldOwner),
+	}...); err != nil {
+		return fmt.Errorf("applying object in remote cluster: %w", err)
+	}
+
+	log.V(4).Info("object was applied", "gvk", gvk, "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	return nil
+}
+
 func getTrustedCAcert(ctx context.Context, cl client.Client, agentTLSModeFeatureEnabled bool) ([]byte, error) {
 	log := log.FromContext(ctx)
 
@@ -18,10 +18,13 @@ package controllers
 
 import (
 	"context"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -122,3 +125,98 @@ var _ = Describe("getTrustedCAcert", func() {
 		Expect(result).To(BeNil())
 	})
 })
+
+var _ = Describe("createImportManifest", func() {
+	const manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: agent-config
+  namespace: default
+data:
+  token: current
+`
+
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		configMap  *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent-config",
+				Namespace: "default",
+			},
+			Data: map[string]string{"token": "rotated"},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build()
+	})
+
+	It("should leave existing objects untouched when creating", func() {
+		Expect(createImportManifest(ctx, fakeClient, strings.NewReader(manifest), false)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKeyWithValue("token", "rotated"))
+	})
+
+	It("should repair drifted objects when server-side applying", func() {
+		Expect(createImportManifest(ctx, fakeClient, strings.NewReader(manifest), true)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKeyWithValue("token", "current"))
+	})
+})
+
+var _ = Describe("regenerateRegistrationToken", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		token      *managementv3.ClusterRegistrationToken
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		token = &managementv3.ClusterRegistrationToken{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "c-abcde",
+				Namespace: "c-abcde",
+			},
+			Spec: managementv3.ClusterRegistrationTokenSpec{
+				ClusterName: "c-abcde",
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(token).Build()
+	})
+
+	It("should replace the token created before the re-import request", func() {
+		requeue, err := regenerateRegistrationToken(ctx, fakeClient, "c-abcde", "c-abcde", "1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeTrue())
+
+		requeue, err = regenerateRegistrationToken(ctx, fakeClient, "c-abcde", "c-abcde", "1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeFalse())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(token), token)).To(Succeed())
+		Expect(token.Annotations).To(HaveKeyWithValue(turtlesannotations.ReimportAnnotation, "1"))
+		Expect(token.Spec.ClusterName).To(Equal("c-abcde"))
+	})
+
+	It("should keep the token already created for the re-import request", func() {
+		token.Annotations = map[string]string{turtlesannotations.ReimportAnnotation: "1"}
+		Expect(fakeClient.Update(ctx, token)).To(Succeed())
+
+		requeue, err := regenerateRegistrationToken(ctx, fakeClient, "c-abcde", "c-abcde", "1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeFalse())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(token), token)).To(Succeed())
+		Expect(token.Annotations).To(HaveKeyWithValue(turtlesannotations.ReimportAnnotation, "1"))
+	})
+})
@@ -19,8 +19,10 @@ package controllers
 import (
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -43,9 +46,11 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
 	"github.com/rancher/turtles/feature"
 	"github.com/rancher/turtles/util"
 	turtlesannotations "github.com/rancher/turtles/util/annotations"
@@ -54,6 +59,11 @@ import (
 
 const (
 	missingLabelMsg = "missing label"
+	// defaultAgentDisconnectThreshold is the default time a previously connected agent can be disconnected
+	// before the import manifest is applied again.
+	defaultAgentDisconnectThreshold = 5 * time.Minute
+	// lastAgentReapplyAnnotation holds the time the import manifest was last re-applied for a disconnected agent.
+	lastAgentReapplyAnnotation = "cluster-api.cattle.io/last-agent-reapply"
 	// FleetAddonFinalizer is the finalizer added by CAAPF to guard cleanup.
 	FleetAddonFinalizer = "fleet.addons.cluster.x-k8s.io"
 )
@@ -62,11 +72,30 @@ const (
 type CAPIImportReconciler struct {
 	Client             client.Client
 	UncachedClient     client.Client
+	RancherClient      client.Client
+	RancherCache       cache.Cache
 	recorder           record.EventRecorder
 	WatchFilterValue   string
 	Scheme             *runtime.Scheme
 	InsecureSkipVerify bool
 
+	// PropagateLabels and PropagateAnnotations are the allow-lists of CAPI cluster label and annotation keys
+	// synced to the Rancher cluster. Entries ending with '/' match every key with that prefix.
+	PropagateLabels      []string
+	PropagateAnnotations []string
+
+	// ServerSideApply enables server-side apply of the import manifest, repairing drift of the already existing
+	// agent objects. It can be overridden per cluster with the import-server-side-apply annotation.
+	ServerSideApply bool
+	// AgentDisconnectThreshold is the time a previously connected agent can be disconnected before
+	// the import manifest is applied again.
+	AgentDisconnectThreshold time.Duration
+	// AgentCleanup removes the Rancher agent objects from the downstream cluster when the Rancher cluster is deleted
+	// with the Cascade deletion policy, before the Rancher cluster is released.
+	AgentCleanup bool
+	// ManifestDownload configures the HTTP client downloading the registration manifests from Rancher.
+	ManifestDownload ManifestDownloadOptions
+
 	controller         controller.Controller
 	externalTracker    external.ObjectTracker
 	remoteClientGetter remote.ClusterClientGetter
@@ -80,9 +109,21 @@ func (r *CAPIImportReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		r.remoteClientGetter = remote.NewClusterClient
 	}
 
+	// Rancher Manager is running in the same cluster unless configured otherwise.
+	if r.RancherClient == nil {
+		r.RancherClient = r.Client
+	}
+
+	if r.RancherCache == nil {
+		r.RancherCache = mgr.GetCache()
+	}
+
+	r.AgentDisconnectThreshold = cmp.Or(r.AgentDisconnectThreshold, defaultAgentDisconnectThreshold)
+
 	capiPredicates := predicates.All(r.Scheme, log,
 		predicates.ResourceHasFilterLabel(r.Scheme, log, r.WatchFilterValue),
 		turtlespredicates.ClusterWithoutImportedAnnotation(log),
+		turtlespredicates.ClusterNotPaused(log),
 		turtlespredicates.ClusterWithReadyControlPlane(log),
 		turtlespredicates.ClusterOrNamespaceWithImportLabel(ctx, log, r.Client, importLabelName),
 	)
@@ -98,7 +139,7 @@ func (r *CAPIImportReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 
 	// Watch Rancher managementv3 clusters
 	if err := c.Watch(
-		source.Kind[client.Object](mgr.GetCache(), &managementv3.Cluster{},
+		source.Kind[client.Object](r.RancherCache, &managementv3.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.rancherV3ClusterToCapiCluster(ctx, capiPredicates)),
 		)); err != nil {
 		return fmt.Errorf("adding watch for Rancher cluster: %w", err)
@@ -112,6 +153,17 @@ func (r *CAPIImportReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		return fmt.Errorf("adding watch for namespaces: %w", err)
 	}
 
+	if err = c.Watch(
+		source.Kind[client.Object](mgr.GetCache(), &turtlesv1.ClusterImportPolicy{},
+			handler.EnqueueRequestsFromMapFunc(clusterImportPolicyToCapiClusters(ctx, capiPredicates, r.Client)),
+		)); err != nil {
+		return fmt.Errorf("adding watch for cluster import policies: %w", err)
+	}
+
+	if err := registerRancherClusterCollector(r.RancherClient); err != nil {
+		return fmt.Errorf("registering Rancher cluster metrics: %w", err)
+	}
+
 	r.recorder = mgr.GetEventRecorderFor("rancher-turtles")
 	r.controller = c
 	r.externalTracker = external.ObjectTracker{
@@ -128,7 +180,10 @@ func (r *CAPIImportReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=management.cattle.io,resources=clusters;clusters/status;clusterregistrationtokens,verbs=get;list;watch;create;update;delete;deletecollection;patch
 // +kubebuilder:rbac:groups=management.cattle.io,resources=clusterregistrationtokens/status;settings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=management.cattle.io,resources=clusterroletemplatebindings;projects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=provisioning.cattle.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=turtles-capi.cattle.io,resources=clusterimportpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch;delete
 //
 //nolint:lll
 
@@ -151,6 +206,29 @@ func (r *CAPIImportReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	log = log.WithValues("cluster", capiCluster.Name)
 
+	// A paused cluster is left untouched, together with its Rancher cluster. This should only happen
+	// on requeues, as the predicates do the filtering.
+	if turtlesannotations.IsPaused(capiCluster) {
+		log.Info("CAPI cluster is paused, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	if turtlesannotations.HasClusterImportAnnotation(capiCluster) && turtlesannotations.HasPendingReimport(capiCluster) {
+		log.Info("CAPI cluster re-import is requested, removing imported annotation")
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster); err != nil {
+				return err
+			}
+
+			delete(capiCluster.Annotations, turtlesannotations.ClusterImportedAnnotation)
+
+			return r.Client.Update(ctx, capiCluster)
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error removing imported annotation: %w", err)
+		}
+	}
+
 	if capiCluster.DeletionTimestamp.IsZero() &&
 		!turtlesannotations.HasClusterImportAnnotation(capiCluster) &&
 		!controllerutil.ContainsFinalizer(capiCluster, managementv3.CapiClusterFinalizer) {
@@ -169,29 +247,49 @@ func (r *CAPIImportReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	if turtlesannotations.HasClusterImportAnnotation(capiCluster) {
+		log.Info("cluster was imported already and has imported=true annotation set, skipping re-import")
+		return ctrl.Result{}, nil
+	}
+
+	// The patch helper only updates the conditions owned by Turtles, preserving the ones set by CAPI controllers.
+	patchHelper, err := patch.NewHelper(capiCluster, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create patch helper: %w", err)
+	}
+
+	patchOpts := []patch.Option{
+		patch.WithOwnedConditions{Conditions: []string{turtlesv1.RancherImportedCondition, turtlesv1.RancherClusterUniqueCondition}},
+	}
+
 	// Wait for controlplane to be ready. This should never be false as the predicates
 	// do the filtering.
 	if !conditions.IsTrue(capiCluster, clusterv1.ClusterControlPlaneAvailableCondition) {
 		log.Info("clusters control plane is not ready, requeue")
-		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
-	}
 
-	if turtlesannotations.HasClusterImportAnnotation(capiCluster) {
-		log.Info("cluster was imported already and has imported=true annotation set, skipping re-import")
-		return ctrl.Result{}, nil
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportWaitingForControlPlaneReason,
+			"Waiting for the cluster control plane to become available")
+
+		if err := patchHelper.Patch(ctx, capiCluster, patchOpts...); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch cluster: %w", err)
+		}
+
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 	}
 
 	// Collect errors as an aggregate to return together after all patches have been performed.
 	var errs []error
 
-	patchBase := client.MergeFromWithOptions(capiCluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
-
 	result, err := r.reconcile(ctx, capiCluster)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("error reconciling cluster: %w", err))
+
+		if capiCluster.DeletionTimestamp.IsZero() {
+			r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportFailedReason, "%s", err.Error())
+		}
 	}
 
-	if err := r.Client.Patch(ctx, capiCluster, patchBase); err != nil {
+	if err := patchHelper.Patch(ctx, capiCluster, patchOpts...); err != nil {
 		errs = append(errs, fmt.Errorf("failed to patch cluster: %w", err))
 	}
 
@@ -218,31 +316,88 @@ func (r *CAPIImportReconciler) reconcile(ctx context.Context, capiCluster *clust
 		client.MatchingLabels(labels),
 	}
 
-	if err := r.Client.List(ctx, rancherClusterList, selectors...); client.IgnoreNotFound(err) != nil {
+	if err := r.RancherClient.List(ctx, rancherClusterList, selectors...); client.IgnoreNotFound(err) != nil {
 		log.Error(err, fmt.Sprintf("Unable to fetch rancher cluster %s", client.ObjectKeyFromObject(rancherCluster)))
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
 
+	// A CAPI cluster moved from another management cluster keeps its name and namespace, so it still matches the owner
+	// labels of its Rancher cluster. When these labels are missing, the Rancher cluster of the binding is adopted.
+	if len(rancherClusterList.Items) == 0 && capiCluster.DeletionTimestamp.IsZero() {
+		if err := r.adoptMovedRancherCluster(ctx, capiCluster, rancherClusterList); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if len(rancherClusterList.Items) != 0 {
+		rancherCluster = canonicalRancherCluster(capiCluster, rancherClusterList.Items)
+
 		if len(rancherClusterList.Items) > 1 {
-			log.Info("More than one rancher cluster found. Will default to using the first one.")
+			log.Info("More than one rancher cluster found, removing duplicates", "canonical", rancherCluster.Name)
+
+			if err := r.removeDuplicateRancherClusters(ctx, capiCluster, rancherCluster, rancherClusterList.Items); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error removing duplicate rancher clusters: %w", err)
+			}
+
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 		}
 
-		rancherCluster = &rancherClusterList.Items[0]
+		conditions.Set(capiCluster, metav1.Condition{
+			Type:    turtlesv1.RancherClusterUniqueCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  turtlesv1.RancherClusterUniqueReason,
+			Message: fmt.Sprintf("Cluster is imported as Rancher cluster %s", rancherCluster.Name),
+		})
 	}
 
 	// Reconcile ManagementV3 Cluster deletion.
 	if rancherCluster != nil && !rancherCluster.DeletionTimestamp.IsZero() {
-		// Patch CAPI Cluster with:
-		// 1. `imported=true` annotation to prevent further-reimports.
-		// 2. Removed capicluster.turtles.cattle.io finalizer to allow deletion.
-		if err := r.reconcileDelete(ctx, capiCluster); err != nil {
-			log.Error(err, "Removing CAPI Cluster failed, retrying")
+		deletionPolicy, err := r.deletionPolicy(ctx, capiCluster)
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		if controllerutil.RemoveFinalizer(rancherCluster, managementv3.CapiClusterFinalizer) {
-			if err := r.Client.Update(ctx, rancherCluster); err != nil {
+		if deletionPolicy == turtlesv1.DeletionPolicyOrphan {
+			// Request a re-import, so the agent is registered with the Rancher cluster created next.
+			if !turtlesannotations.HasPendingReimport(capiCluster) {
+				annotations := capiCluster.GetAnnotations()
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+
+				annotations[turtlesannotations.ReimportAnnotation] = time.Now().UTC().Format(time.RFC3339)
+				capiCluster.SetAnnotations(annotations)
+			}
+
+			r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "RancherClusterDeleted",
+				"Rancher cluster %s is being deleted, the cluster will be imported again", rancherCluster.Name)
+		} else {
+			if deletionPolicy == turtlesv1.DeletionPolicyCascade && r.AgentCleanup && capiCluster.DeletionTimestamp.IsZero() {
+				if requeue, err := r.cleanupAgent(ctx, capiCluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("error removing rancher agent: %w", err)
+				} else if requeue {
+					r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportAgentCleanupReason,
+						"Removing the Rancher agent of deleted Rancher cluster %s", rancherCluster.Name)
+
+					return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+				}
+			}
+
+			// Patch CAPI Cluster with:
+			// 1. `imported=true` annotation to prevent further-reimports.
+			// 2. Removed capicluster.turtles.cattle.io finalizer to allow deletion.
+			if err := r.reconcileDelete(ctx, capiCluster); err != nil {
+				log.Error(err, "Removing CAPI Cluster failed, retrying")
+				return ctrl.Result{}, err
+			}
+
+			r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "RancherClusterDeleted",
+				"Rancher cluster %s is being deleted, the cluster won't be imported again", rancherCluster.Name)
+		}
+
+		removedFinalizer := controllerutil.RemoveFinalizer(rancherCluster, managementv3.AgentCleanupFinalizer)
+		if controllerutil.RemoveFinalizer(rancherCluster, managementv3.CapiClusterFinalizer) || removedFinalizer {
+			if err := r.RancherClient.Update(ctx, rancherCluster); err != nil {
 				return ctrl.Result{}, fmt.Errorf("error removing rancher cluster finalizer: %w", err)
 			}
 		}
@@ -251,9 +406,27 @@ func (r *CAPIImportReconciler) reconcile(ctx context.Context, capiCluster *clust
 	}
 
 	// Reconcile CAPI Cluster deletion.
+	// A CAPI cluster moved by clusterctl is paused, and its finalizers are removed before it is deleted from the
+	// source management cluster, so the Rancher cluster is kept without reaching this point.
 	if !capiCluster.DeletionTimestamp.IsZero() {
-		if err := r.deleteDependentRancherCluster(ctx, capiCluster); err != nil {
-			return ctrl.Result{}, fmt.Errorf("error deleting associated managementv3.Cluster resources: %w", err)
+		deletionPolicy, err := r.deletionPolicy(ctx, capiCluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if deletionPolicy == turtlesv1.DeletionPolicyCascade {
+			// The downstream cluster is going away, its agent does not need to be removed.
+			if rancherCluster != nil && controllerutil.RemoveFinalizer(rancherCluster, managementv3.AgentCleanupFinalizer) {
+				if err := r.RancherClient.Update(ctx, rancherCluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("error removing rancher cluster finalizer: %w", err)
+				}
+			}
+
+			if err := r.deleteDependentRancherCluster(ctx, capiCluster); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error deleting associated managementv3.Cluster resources: %w", err)
+			}
+		} else if err := r.releaseRancherCluster(ctx, capiCluster, rancherCluster); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error releasing associated managementv3.Cluster: %w", err)
 		}
 
 		if controllerutil.RemoveFinalizer(capiCluster, managementv3.CapiClusterFinalizer) {
@@ -274,11 +447,17 @@ func (r *CAPIImportReconciler) reconcile(ctx context.Context, capiCluster *clust
 			return
 		}
 
-		if err := r.Client.Patch(ctx, rancherCluster, patchBase); err != nil {
+		if err := r.RancherClient.Patch(ctx, rancherCluster, patchBase); err != nil {
 			reterr = fmt.Errorf("failed to patch Rancher cluster: %w", err)
 		}
 	}()
 
+	if rancherCluster != nil {
+		if err := r.reconcileRancherBinding(ctx, capiCluster, rancherCluster); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	res, reterr = r.reconcileNormal(ctx, capiCluster, rancherCluster)
 
 	return res, reterr
@@ -293,8 +472,8 @@ func (r *CAPIImportReconciler) reconcileNormal(ctx context.Context, capiCluster
 
 	updatedCluster := &managementv3.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:    capiCluster.Namespace,
-			GenerateName: "c-",
+			Namespace: capiCluster.Namespace,
+			Name:      rancherClusterName(capiCluster),
 			Labels: map[string]string{
 				capiClusterOwner:          capiCluster.Name,
 				capiClusterOwnerNamespace: capiCluster.Namespace,
@@ -308,21 +487,22 @@ func (r *CAPIImportReconciler) reconcileNormal(ctx context.Context, capiCluster
 				managementv3.CapiClusterFinalizer,
 			},
 		},
-		Spec: managementv3.ClusterSpec{
-			DisplayName: capiCluster.Name,
-			Description: func() string {
-				annotation := capiCluster.Annotations[turtlesannotations.ClusterDescriptionAnnotation]
-				if annotation == "" {
-					return "CAPI cluster imported to Rancher"
-				}
-
-				return annotation
-			}(),
-		},
 	}
 
 	rancherCluster = cmp.Or(rancherCluster, updatedCluster)
 
+	defaults, err := importDefaults(ctx, r.Client, capiCluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Report an invalid deletion policy on import, instead of only when the cluster is deleted.
+	r.resolveDeletionPolicy(capiCluster, defaults)
+
+	if err := r.reconcileMetadata(ctx, capiCluster, rancherCluster, defaults); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	r.optOutOfClusterOwner(ctx, rancherCluster)
 	r.reconcileExternalFleetManagement(ctx, rancherCluster, capiCluster)
 
@@ -331,15 +511,43 @@ func (r *CAPIImportReconciler) reconcileNormal(ctx context.Context, capiCluster
 		log.Info("Successfully added capicluster.turtles.cattle.io finalizer to Rancher cluster")
 	}
 
+	if r.AgentCleanup {
+		controllerutil.AddFinalizer(rancherCluster, managementv3.AgentCleanupFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(rancherCluster, managementv3.AgentCleanupFinalizer)
+	}
+
 	if clusterMissing {
 		if autoImport, err := r.shouldAutoImportUncached(ctx, capiCluster); err != nil || !autoImport {
 			return ctrl.Result{}, err
 		}
 
-		if err := r.Client.Create(ctx, rancherCluster); err != nil {
+		err := r.RancherClient.Create(ctx, rancherCluster)
+		if apierrors.IsAlreadyExists(err) {
+			existing := &managementv3.Cluster{}
+			if err := r.RancherClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), existing); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("error getting existing rancher cluster: %w", err)
+			} else if err != nil || ownsRancherCluster(capiCluster, existing) {
+				log.Info("Rancher cluster already exists but is not observed yet, requeue", "rancherCluster", rancherCluster.Name)
+
+				return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+			}
+
+			// The name is taken by a Rancher cluster released by Turtles, fall back to a generated name.
+			rancherCluster.Name = ""
+			rancherCluster.GenerateName = "c-"
+			err = r.RancherClient.Create(ctx, rancherCluster)
+		}
+
+		if err != nil {
+			importFailuresTotal.WithLabelValues(importFailureClusterCreate).Inc()
 			return ctrl.Result{}, fmt.Errorf("error creating rancher cluster: %w", err)
 		}
 
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "RancherClusterCreated", "Created Rancher cluster %s", rancherCluster.Name)
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportWaitingForRegistrationTokenReason,
+			"Waiting for the registration token of Rancher cluster %s", rancherCluster.Name)
+
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 	}
 
@@ -351,66 +559,364 @@ func (r *CAPIImportReconciler) reconcileNormal(ctx context.Context, capiCluster
 	annotations := rancherCluster.GetAnnotations()
 	fleetMigrated = annotations[fleetNamespaceMigrated] == "cattle-fleet-system" || fleetMigrated
 
-	if conditions.IsTrue(rancherCluster, managementv3.ClusterConditionReady) && fleetMigrated {
+	reimport := turtlesannotations.HasPendingReimport(capiCluster)
+	ready := conditions.IsTrue(rancherCluster, managementv3.ClusterConditionReady) && !reimport
+
+	mode, err := r.importMode(capiCluster, defaults)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if mode == turtlesv1.ImportModePush {
+		if err := r.cleanupImportResourceSet(ctx, capiCluster); err != nil {
+			importFailuresTotal.WithLabelValues(importFailureResourceSet).Inc()
+			return ctrl.Result{}, err
+		}
+	}
+
+	if ready {
+		if err := r.reconcileClusterAccess(ctx, capiCluster, rancherCluster); err != nil {
+			importFailuresTotal.WithLabelValues(importFailureClusterAccess).Inc()
+			return ctrl.Result{}, fmt.Errorf("reconciling rancher cluster access: %w", err)
+		}
+	}
+
+	if ready && fleetMigrated {
 		log.Info("agent is ready, no action needed")
 
+		r.observeImportDuration(capiCluster)
+		r.setImportedCondition(capiCluster, metav1.ConditionTrue, turtlesv1.RancherImportFleetMigratedReason,
+			"Cluster is imported into Rancher as %s", rancherCluster.Name)
+
 		return ctrl.Result{}, nil
-	} else if conditions.IsTrue(rancherCluster, managementv3.ClusterConditionReady) {
+	} else if ready {
+		r.observeImportDuration(capiCluster)
+		if mode == turtlesv1.ImportModePull {
+			log.Info("agent is ready, fleet agent namespace migration requires access to the cluster and is skipped in Pull mode")
+
+			r.setImportedCondition(capiCluster, metav1.ConditionTrue, turtlesv1.RancherImportAgentConnectedReason,
+				"Cluster is imported into Rancher as %s", rancherCluster.Name)
+
+			return ctrl.Result{}, nil
+		}
+
+		r.setImportedCondition(capiCluster, metav1.ConditionTrue, turtlesv1.RancherImportAgentConnectedReason,
+			"Rancher agent is connected, migrating the fleet agent namespace")
+
 		// Delete old agent namespace on the downstream cluster
 		remoteClient, err := r.remoteClientGetter(ctx, capiCluster.Name, r.Client, client.ObjectKeyFromObject(capiCluster))
 		if err != nil {
+			importFailuresTotal.WithLabelValues(importFailureRemoteClient).Inc()
 			return ctrl.Result{}, fmt.Errorf("getting remote cluster client: %w", err)
 		}
 
 		if requeue, err := removeFleetNamespace(ctx, remoteClient, rancherCluster); err != nil {
+			fleetNamespaceMigrationsTotal.WithLabelValues(fleetMigrationError).Inc()
+			importFailuresTotal.WithLabelValues(importFailureFleetMigration).Inc()
+
 			return ctrl.Result{}, fmt.Errorf("cleaning up fleet namespace: %w", err)
 		} else if requeue {
+			fleetNamespaceMigrationsTotal.WithLabelValues(fleetMigrationPending).Inc()
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+		}
+
+		fleetNamespaceMigrationsTotal.WithLabelValues(fleetMigrationMigrated).Inc()
+
+		return ctrl.Result{}, nil
+	}
+
+	serverSideApply := r.serverSideApplyEnabled(capiCluster, defaults)
+	disconnected, wasConnected := agentDisconnectedFor(capiCluster, rancherCluster)
+
+	if reimport {
+		// A requested re-import replaces the agent registration regardless of the agent state,
+		// so existing agent objects have to be updated.
+		wasConnected = false
+		serverSideApply = true
+
+		requeue, err := regenerateRegistrationToken(ctx, r.RancherClient, rancherCluster.Name, rancherCluster.Name,
+			capiCluster.GetAnnotations()[turtlesannotations.ReimportAnnotation])
+		if err != nil {
+			importFailuresTotal.WithLabelValues(importFailureRegistrationToken).Inc()
+			return ctrl.Result{}, fmt.Errorf("regenerating registration token: %w", err)
+		} else if requeue {
+			r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportWaitingForRegistrationTokenReason,
+				"Regenerating the registration token of Rancher cluster %s for re-import", rancherCluster.Name)
+
 			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 		}
+	}
+
+	// Give a previously connected agent the chance to reconnect before re-applying the manifest.
+	if wasConnected && disconnected < r.AgentDisconnectThreshold {
+		wait := r.AgentDisconnectThreshold - disconnected
+
+		log.Info("Rancher agent is disconnected, waiting before re-applying the import manifest", "after", wait)
+
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportAgentDisconnectedReason,
+			"Rancher agent is disconnected, re-applying the import manifest in %s", wait.Round(time.Second))
+
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	if err := validateSystemAgent(capiCluster); err != nil {
+		log.Info("Invalid system-agent install source, not importing the cluster", "error", err.Error())
+
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportInvalidSystemAgentReason, "%s", err.Error())
 
 		return ctrl.Result{}, nil
 	}
 
 	// Get custom CAcert if agentTLSMode feature is enabled
-	caCert, err := getTrustedCAcert(ctx, r.Client, feature.Gates.Enabled(feature.AgentTLSMode))
+	caCert, err := getTrustedCAcert(ctx, r.RancherClient, feature.Gates.Enabled(feature.AgentTLSMode))
 	if err != nil {
+		importFailuresTotal.WithLabelValues(importFailureCACert).Inc()
 		return ctrl.Result{}, fmt.Errorf("error getting CA cert: %w", err)
 	}
 
-	// get the registration manifest
-	manifest, err := getClusterRegistrationManifest(ctx, rancherCluster.Name, rancherCluster.Name, r.Client, caCert, r.InsecureSkipVerify)
-	if err != nil {
+	var manifest string
+
+	downloader, err := r.manifestDownloader(ctx, caCert)
+	if err == nil {
+		// get the registration manifest
+		manifest, err = getClusterRegistrationManifest(ctx, rancherCluster.Name, rancherCluster.Name, r.RancherClient, downloader)
+	}
+
+	// Download failures are reported on the condition and retried in the following reconciles.
+	var downloadErr *manifestDownloadError
+	if errors.As(err, &downloadErr) {
+		importFailuresTotal.WithLabelValues(importFailureManifestDownload).Inc()
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportManifestDownloadFailedReason, "%s", err.Error())
+
+		if downloader == nil {
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+		}
+
+		return ctrl.Result{RequeueAfter: downloader.retryAfter(ctx, capiCluster, downloadErr)}, nil
+	} else if err != nil {
+		importFailuresTotal.WithLabelValues(importFailureRegistrationToken).Inc()
 		return ctrl.Result{}, err
 	}
 
+	if annotations := capiCluster.GetAnnotations(); annotations != nil {
+		delete(annotations, manifestDownloadRetriesAnnotation)
+	}
+
 	if manifest == "" {
 		log.Info("Import manifest URL not set yet, requeue")
+
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportWaitingForRegistrationTokenReason,
+			"Waiting for the registration token of Rancher cluster %s", rancherCluster.Name)
+
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 	}
 
+	manifest, err = alterAgentManifest(manifest, append(agentManifestAlterFns(defaults.Agent), systemAgentAlterFns(capiCluster)...))
+	if err != nil {
+		importFailuresTotal.WithLabelValues(importFailureManifestAlter).Inc()
+		return ctrl.Result{}, fmt.Errorf("altering import manifest: %w", err)
+	}
+
 	log.Info("Creating import manifest")
 
+	importAttemptsTotal.Inc()
+
+	if wasConnected {
+		log.Info("Rancher agent is disconnected, re-importing the cluster", "disconnected", disconnected)
+
+		reimportsTotal.Inc()
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "ReimportingCluster",
+			"Rancher agent has been disconnected for %s, re-applying the import manifest", disconnected.Round(time.Second))
+	}
+
+	if mode == turtlesv1.ImportModePull {
+		if err := r.reconcileImportResourceSet(ctx, capiCluster, manifest); err != nil {
+			importFailuresTotal.WithLabelValues(importFailureResourceSet).Inc()
+			return ctrl.Result{}, err
+		}
+	} else if res, err := r.applyImportManifest(ctx, capiCluster, manifest, serverSideApply); err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	if reimport {
+		annotations := capiCluster.GetAnnotations()
+		annotations[turtlesannotations.ReimportHandledAnnotation] = annotations[turtlesannotations.ReimportAnnotation]
+		capiCluster.SetAnnotations(annotations)
+
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "ClusterReimported",
+			"Re-import %q handled, import manifest applied with a new registration token", annotations[turtlesannotations.ReimportAnnotation])
+	}
+
+	if wasConnected {
+		annotations := rancherCluster.GetAnnotations()
+		annotations[lastAgentReapplyAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		rancherCluster.SetAnnotations(annotations)
+
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportAgentDisconnectedReason,
+			"Import manifest re-applied, waiting for the Rancher agent to reconnect")
+
+		return ctrl.Result{RequeueAfter: r.AgentDisconnectThreshold}, nil
+	}
+
+	switch {
+	case mode != turtlesv1.ImportModePull:
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportManifestAppliedReason,
+			"Import manifest applied, waiting for the Rancher agent to connect")
+	case conditions.IsTrue(rancherCluster, managementv3.ClusterConditionAgentDeployed):
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportAgentDeployedReason,
+			"Rancher agent is deployed, waiting for it to connect")
+	default:
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportManifestDeliveredReason,
+			"Import manifest delivered with ClusterResourceSet %s, waiting for the Rancher agent to be deployed",
+			capiCluster.Name+importResourceSetSuffix)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// applyImportManifest applies the import manifest to the workload cluster with the remote cluster client.
+// A non-zero result is returned when the manifest can't be applied yet.
+func (r *CAPIImportReconciler) applyImportManifest(ctx context.Context, capiCluster *clusterv1.Cluster, manifest string,
+	serverSideApply bool,
+) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
 	remoteClient, err := r.remoteClientGetter(ctx, capiCluster.Name, r.Client, client.ObjectKeyFromObject(capiCluster))
 	if err != nil {
+		importFailuresTotal.WithLabelValues(importFailureRemoteClient).Inc()
 		return ctrl.Result{}, fmt.Errorf("getting remote cluster client: %w", err)
 	}
 
 	if requeue, err := validateImportReadiness(ctx, remoteClient, strings.NewReader(manifest)); err != nil {
+		importFailuresTotal.WithLabelValues(importFailureManifestValidation).Inc()
 		return ctrl.Result{}, fmt.Errorf("verifying import manifest: %w", err)
 	} else if requeue {
 		log.Info("Import manifests are being deleted, not ready to be applied yet, requeue")
+
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportWaitingForCleanupReason,
+			"Waiting for the previous Rancher agent installation to be removed")
+
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 	}
 
-	if err := createImportManifest(ctx, remoteClient, strings.NewReader(manifest)); err != nil {
+	if err := createImportManifest(ctx, remoteClient, strings.NewReader(manifest), serverSideApply); err != nil {
+		importFailuresTotal.WithLabelValues(importFailureManifestApply).Inc()
 		return ctrl.Result{}, fmt.Errorf("creating import manifest: %w", err)
 	}
 
 	log.Info("Successfully applied import manifest")
 
+	if r.AgentCleanup {
+		if err := r.recordAgentObjects(ctx, capiCluster, manifest); err != nil {
+			return ctrl.Result{}, fmt.Errorf("recording rancher agent objects: %w", err)
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// serverSideApplyEnabled returns true if the import manifest should be server-side applied to the cluster.
+// The cluster annotation takes precedence over the import policy, which takes precedence over the controller setting.
+func (r *CAPIImportReconciler) serverSideApplyEnabled(capiCluster *clusterv1.Cluster, defaults turtlesv1.ClusterImportPolicySpec) bool {
+	if value, found := capiCluster.GetAnnotations()[turtlesannotations.ImportServerSideApplyAnnotation]; found {
+		return value == trueValue
+	}
+
+	if defaults.Agent.ServerSideApply != nil {
+		return *defaults.Agent.ServerSideApply
+	}
+
+	return r.ServerSideApply
+}
+
+// importDefaults returns the defaults of the ClusterImportPolicy selecting the CAPI cluster,
+// or empty defaults when no policy selects it.
+func importDefaults(ctx context.Context, cl client.Client, capiCluster *clusterv1.Cluster) (turtlesv1.ClusterImportPolicySpec, error) {
+	policy, err := util.ClusterImportPolicy(ctx, cl, capiCluster)
+	if err != nil {
+		return turtlesv1.ClusterImportPolicySpec{}, fmt.Errorf("getting cluster import policy: %w", err)
+	}
+
+	if policy == nil {
+		return turtlesv1.ClusterImportPolicySpec{}, nil
+	}
+
+	log.FromContext(ctx).V(4).Info("Using cluster import policy defaults", "policy", policy.Name)
+
+	return policy.Spec, nil
+}
+
+// agentDisconnectedFor returns for how long the Rancher agent has been disconnected, if it was connected before.
+// The time is counted from the last re-apply of the import manifest, if it happened after the agent disconnected.
+func agentDisconnectedFor(capiCluster *clusterv1.Cluster, rancherCluster *managementv3.Cluster) (time.Duration, bool) {
+	switch conditions.GetReason(capiCluster, turtlesv1.RancherImportedCondition) {
+	case turtlesv1.RancherImportAgentConnectedReason,
+		turtlesv1.RancherImportFleetMigratedReason,
+		turtlesv1.RancherImportAgentDisconnectedReason:
+	default:
+		return 0, false
+	}
+
+	ready := conditions.Get(rancherCluster, managementv3.ClusterConditionReady)
+	if ready == nil || ready.Status == metav1.ConditionTrue {
+		return 0, false
+	}
+
+	since := ready.LastTransitionTime.Time
+
+	lastReapply, err := time.Parse(time.RFC3339, rancherCluster.GetAnnotations()[lastAgentReapplyAnnotation])
+	if err == nil && lastReapply.After(since) {
+		since = lastReapply
+	}
+
+	return time.Since(since), true
+}
+
+// observeImportDuration records the time it took for the Rancher cluster to become Ready after the CAPI
+// cluster control plane became available. It is only recorded once, for imports this controller delivered
+// the import manifest of: clusters imported before, reconnecting agents and failed imports are skipped.
+func (r *CAPIImportReconciler) observeImportDuration(capiCluster *clusterv1.Cluster) {
+	switch conditions.GetReason(capiCluster, turtlesv1.RancherImportedCondition) {
+	case turtlesv1.RancherImportManifestAppliedReason,
+		turtlesv1.RancherImportManifestDeliveredReason,
+		turtlesv1.RancherImportAgentDeployedReason:
+	default:
+		return
+	}
+
+	controlPlaneAvailable := conditions.Get(capiCluster, clusterv1.ClusterControlPlaneAvailableCondition)
+	if controlPlaneAvailable == nil {
+		return
+	}
+
+	importDurationSeconds.Observe(time.Since(controlPlaneAvailable.LastTransitionTime.Time).Seconds())
+}
+
+// setImportedCondition sets the RancherImported condition on the CAPI cluster. An event is recorded every time
+// the import moves to a different phase, and on every failure.
+func (r *CAPIImportReconciler) setImportedCondition(capiCluster *clusterv1.Cluster, status metav1.ConditionStatus,
+	reason, messageFormat string, args ...any,
+) {
+	message := fmt.Sprintf(messageFormat, args...)
+
+	switch reason {
+	case turtlesv1.RancherImportFailedReason, turtlesv1.RancherImportManifestDownloadFailedReason,
+		turtlesv1.RancherImportInvalidSystemAgentReason:
+		r.recorder.Event(capiCluster, corev1.EventTypeWarning, reason, message)
+	default:
+		if current := conditions.Get(capiCluster, turtlesv1.RancherImportedCondition); current == nil || current.Reason != reason {
+			r.recorder.Event(capiCluster, corev1.EventTypeNormal, reason, message)
+		}
+	}
+
+	conditions.Set(capiCluster, metav1.Condition{
+		Type:    turtlesv1.RancherImportedCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
 func (r *CAPIImportReconciler) shouldAutoImportUncached(ctx context.Context, capiCluster *clusterv1.Cluster) (bool, error) {
 	log := log.FromContext(ctx)
 
@@ -501,6 +1007,10 @@ func (r *CAPIImportReconciler) deleteDependentRancherCluster(ctx context.Context
 	log := log.FromContext(ctx)
 	log.Info("capi cluster is being deleted, deleting dependent rancher cluster")
 
+	if err := r.deleteClusterAccess(ctx, capiCluster); err != nil {
+		return fmt.Errorf("deleting rancher cluster access: %w", err)
+	}
+
 	selectors := []client.DeleteAllOfOption{
 		client.MatchingLabels{
 			capiClusterOwner:          capiCluster.Name,
@@ -509,7 +1019,110 @@ func (r *CAPIImportReconciler) deleteDependentRancherCluster(ctx context.Context
 		},
 	}
 
-	return client.IgnoreNotFound(r.Client.DeleteAllOf(ctx, &managementv3.Cluster{}, selectors...))
+	return client.IgnoreNotFound(r.RancherClient.DeleteAllOf(ctx, &managementv3.Cluster{}, selectors...))
+}
+
+// releaseRancherCluster removes the Turtles ownership labels and finalizer from the Rancher cluster, leaving it in place
+// when the CAPI cluster is deleted or the Rancher cluster is a duplicate.
+func (r *CAPIImportReconciler) releaseRancherCluster(ctx context.Context, capiCluster *clusterv1.Cluster,
+	rancherCluster *managementv3.Cluster,
+) error {
+	if rancherCluster == nil {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+	log.Info("Releasing rancher cluster", "rancherCluster", rancherCluster.Name)
+
+	patchBase := client.MergeFromWithOptions(rancherCluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	labels := rancherCluster.GetLabels()
+	delete(labels, ownedLabelName)
+	delete(labels, capiClusterOwner)
+	delete(labels, capiClusterOwnerNamespace)
+	rancherCluster.SetLabels(labels)
+	controllerutil.RemoveFinalizer(rancherCluster, managementv3.CapiClusterFinalizer)
+	controllerutil.RemoveFinalizer(rancherCluster, managementv3.AgentCleanupFinalizer)
+
+	if err := r.RancherClient.Patch(ctx, rancherCluster, patchBase); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "RancherClusterReleased",
+		"Rancher cluster %s is kept and no longer managed by Turtles", rancherCluster.Name)
+
+	return nil
+}
+
+// deletionPolicy returns the deletion policy of the CAPI cluster. The cluster annotation takes precedence
+// over the import policy, and the Cascade policy is used by default.
+func (r *CAPIImportReconciler) deletionPolicy(ctx context.Context, capiCluster *clusterv1.Cluster) (turtlesv1.DeletionPolicy, error) {
+	defaults, err := importDefaults(ctx, r.Client, capiCluster)
+	if err != nil {
+		return "", err
+	}
+
+	return r.resolveDeletionPolicy(capiCluster, defaults), nil
+}
+
+// resolveDeletionPolicy returns the deletion policy of the CAPI cluster with the given import policy defaults.
+// An invalid annotation is reported with a warning event and ignored, so it can't block the cluster deletion.
+func (r *CAPIImportReconciler) resolveDeletionPolicy(capiCluster *clusterv1.Cluster,
+	defaults turtlesv1.ClusterImportPolicySpec,
+) turtlesv1.DeletionPolicy {
+	defaultPolicy := cmp.Or(defaults.DeletionPolicy, turtlesv1.DeletionPolicyCascade)
+	deletionPolicy := turtlesv1.DeletionPolicy(capiCluster.GetAnnotations()[turtlesannotations.DeletionPolicyAnnotation])
+
+	switch deletionPolicy {
+	case "":
+		return defaultPolicy
+	case turtlesv1.DeletionPolicyCascade, turtlesv1.DeletionPolicyOrphan, turtlesv1.DeletionPolicyDetach:
+		return deletionPolicy
+	default:
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "InvalidDeletionPolicy",
+			"Invalid deletion policy %q, expected one of Cascade, Orphan, Detach. Using %s", deletionPolicy, defaultPolicy)
+
+		return defaultPolicy
+	}
+}
+
+// reconcileMetadata propagates the display name, description, Fleet workspace and allow-listed labels and annotations
+// from the CAPI cluster to the Rancher cluster. Changes made directly on the Rancher side are reported and overwritten.
+// The description and Fleet workspace fall back to the import policy defaults.
+func (r *CAPIImportReconciler) reconcileMetadata(ctx context.Context, capiCluster *clusterv1.Cluster, rancherCluster *managementv3.Cluster,
+	defaults turtlesv1.ClusterImportPolicySpec,
+) error {
+	log := log.FromContext(ctx)
+
+	desired := desiredMetadata(capiCluster, r.PropagateLabels, r.PropagateAnnotations)
+	if capiCluster.Annotations[turtlesannotations.ClusterDescriptionAnnotation] == "" && defaults.Description != "" {
+		desired.Description = defaults.Description
+	}
+
+	workspace, err := util.FleetWorkspace(ctx, r.Client, capiCluster, fleetWorkspaceLabel)
+	if err != nil {
+		return fmt.Errorf("getting Fleet workspace: %w", err)
+	}
+
+	desired.FleetWorkspaceName = cmp.Or(workspace, defaults.FleetWorkspace)
+	previousWorkspace := rancherCluster.Spec.FleetWorkspaceName
+
+	conflicts := syncMetadata(rancherCluster, desired)
+	if len(conflicts) != 0 {
+		log.Info("Rancher cluster metadata was modified outside of the CAPI cluster, overwriting", "fields", conflicts)
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "RancherClusterMetadataConflict",
+			"Rancher cluster %s fields were modified directly and are overwritten from the CAPI cluster: %s",
+			rancherCluster.Name, strings.Join(conflicts, ", "))
+	}
+
+	// Only existing clusters are moved, new ones are created in the selected workspace.
+	if !rancherCluster.CreationTimestamp.IsZero() && previousWorkspace != rancherCluster.Spec.FleetWorkspaceName {
+		log.Info("Moving Rancher cluster to a different Fleet workspace", "from", previousWorkspace, "to", rancherCluster.Spec.FleetWorkspaceName)
+		r.recorder.Eventf(capiCluster, corev1.EventTypeNormal, "FleetWorkspaceChanged",
+			"Moving Rancher cluster %s from Fleet workspace %q to %q", rancherCluster.Name, previousWorkspace, rancherCluster.Spec.FleetWorkspaceName)
+	}
+
+	return nil
 }
 
 // optOutOfClusterOwner annotates the cluster with the opt-out annotation.
@@ -17,6 +17,7 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -27,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
 	provisioningv1 "github.com/rancher/turtles/api/rancher/provisioning/v1"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
 	"github.com/rancher/turtles/feature"
 	"github.com/rancher/turtles/internal/controllers/testdata"
 	"github.com/rancher/turtles/internal/test"
@@ -38,12 +40,16 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -80,6 +86,8 @@ var _ = Describe("reconcile CAPI Cluster", func() {
 		r = &CAPIImportReconciler{
 			Client:             cl,
 			UncachedClient:     cl,
+			RancherClient:      cl,
+			recorder:           &record.FakeRecorder{},
 			remoteClientGetter: remote.NewClusterClient,
 			Scheme:             testEnv.GetScheme(),
 		}
@@ -204,6 +212,12 @@ var _ = Describe("reconcile CAPI Cluster", func() {
 			})
 			g.Expect(err).ToNot(HaveOccurred())
 			g.Expect(res.RequeueAfter).To(Equal(defaultRequeueDuration))
+
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+			condition := conditions.Get(capiCluster, turtlesv1.RancherImportedCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal(turtlesv1.RancherImportWaitingForControlPlaneReason))
 		}).Should(Succeed())
 	})
 
@@ -442,6 +456,7 @@ var _ = Describe("reconcile CAPI Cluster", func() {
 
 			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).ToNot(HaveOccurred())
 			g.Expect(capiCluster.Finalizers).To(ContainElement(managementv3.CapiClusterFinalizer))
+			g.Expect(conditions.GetReason(capiCluster, turtlesv1.RancherImportedCondition)).To(Equal(turtlesv1.RancherImportManifestAppliedReason))
 
 			g.Expect(cl.List(ctx, rancherClusters, selectors...)).ToNot(HaveOccurred())
 			g.Expect(rancherClusters.Items).To(HaveLen(1))
@@ -513,6 +528,9 @@ var _ = Describe("reconcile CAPI Cluster", func() {
 			},
 		})
 		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+		Expect(conditions.IsTrue(capiCluster, turtlesv1.RancherImportedCondition)).To(BeTrue())
 	})
 
 	It("should reconcile a CAPI cluster when rancher cluster exists and registration manifests not exist", func() {
@@ -706,3 +724,438 @@ var _ = Describe("reconcile CAPI Cluster", func() {
 		}).Should(Succeed())
 	})
 })
+
+var _ = Describe("RancherImported condition", func() {
+	var (
+		r           *CAPIImportReconciler
+		recorder    *record.FakeRecorder
+		capiCluster *clusterv1.Cluster
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		r = &CAPIImportReconciler{recorder: recorder}
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+			},
+		}
+	})
+
+	It("should record an event only when the import phase changes", func() {
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportManifestAppliedReason, "applied")
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportManifestAppliedReason, "applied")
+		r.setImportedCondition(capiCluster, metav1.ConditionTrue, turtlesv1.RancherImportFleetMigratedReason, "imported as %s", "c-abcde")
+
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(<-recorder.Events).To(Equal("Normal ManifestApplied applied"))
+		Expect(<-recorder.Events).To(Equal("Normal FleetMigrated imported as c-abcde"))
+
+		condition := conditions.Get(capiCluster, turtlesv1.RancherImportedCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(Equal("imported as c-abcde"))
+	})
+
+	It("should record a warning on every failure", func() {
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportFailedReason, "%s", "boom")
+		r.setImportedCondition(capiCluster, metav1.ConditionFalse, turtlesv1.RancherImportFailedReason, "%s", "boom")
+
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(<-recorder.Events).To(Equal("Warning ImportFailed boom"))
+		Expect(conditions.GetReason(capiCluster, turtlesv1.RancherImportedCondition)).To(Equal(turtlesv1.RancherImportFailedReason))
+	})
+})
+
+var _ = Describe("Import manifest re-apply", func() {
+	var (
+		r              *CAPIImportReconciler
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+	)
+
+	BeforeEach(func() {
+		r = &CAPIImportReconciler{}
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+			},
+		}
+		rancherCluster = &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "c-abcde",
+			},
+		}
+	})
+
+	It("should let the cluster annotation override the controller setting", func() {
+		defaults := turtlesv1.ClusterImportPolicySpec{}
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeFalse())
+
+		r.ServerSideApply = true
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeTrue())
+
+		capiCluster.Annotations = map[string]string{turtlesannotations.ImportServerSideApplyAnnotation: "false"}
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeFalse())
+
+		r.ServerSideApply = false
+		capiCluster.Annotations[turtlesannotations.ImportServerSideApplyAnnotation] = "true"
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeTrue())
+	})
+
+	It("should let the import policy override the controller setting", func() {
+		defaults := turtlesv1.ClusterImportPolicySpec{Agent: turtlesv1.ImportAgentSpec{ServerSideApply: ptr.To(true)}}
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeTrue())
+
+		capiCluster.Annotations = map[string]string{turtlesannotations.ImportServerSideApplyAnnotation: "false"}
+		Expect(r.serverSideApplyEnabled(capiCluster, defaults)).To(BeFalse())
+	})
+
+	It("should not report a disconnect for clusters which never connected", func() {
+		conditions.Set(rancherCluster, metav1.Condition{
+			Type:   managementv3.ClusterConditionReady,
+			Status: metav1.ConditionFalse,
+			Reason: "Disconnected",
+		})
+
+		_, wasConnected := agentDisconnectedFor(capiCluster, rancherCluster)
+		Expect(wasConnected).To(BeFalse())
+	})
+
+	It("should report for how long a previously connected agent is disconnected", func() {
+		conditions.Set(capiCluster, metav1.Condition{
+			Type:   turtlesv1.RancherImportedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: turtlesv1.RancherImportFleetMigratedReason,
+		})
+		conditions.Set(rancherCluster, metav1.Condition{
+			Type:               managementv3.ClusterConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Disconnected",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+		})
+
+		disconnected, wasConnected := agentDisconnectedFor(capiCluster, rancherCluster)
+		Expect(wasConnected).To(BeTrue())
+		Expect(disconnected).To(BeNumerically("~", 2*time.Minute, 10*time.Second))
+	})
+
+	It("should count the disconnect from the last re-apply of the import manifest", func() {
+		conditions.Set(capiCluster, metav1.Condition{
+			Type:   turtlesv1.RancherImportedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: turtlesv1.RancherImportAgentDisconnectedReason,
+		})
+		conditions.Set(rancherCluster, metav1.Condition{
+			Type:               managementv3.ClusterConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Disconnected",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		})
+		rancherCluster.Annotations = map[string]string{
+			lastAgentReapplyAnnotation: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+		}
+
+		disconnected, wasConnected := agentDisconnectedFor(capiCluster, rancherCluster)
+		Expect(wasConnected).To(BeTrue())
+		Expect(disconnected).To(BeNumerically("~", time.Minute, 10*time.Second))
+	})
+})
+
+var _ = Describe("Cluster import policy", func() {
+	var (
+		r              *CAPIImportReconciler
+		fakeClient     client.Client
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+		policies       []client.Object
+	)
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+				Labels:    map[string]string{"env": "prod"},
+			},
+		}
+		rancherCluster = &managementv3.Cluster{}
+		policies = []client.Object{
+			&turtlesv1.ClusterImportPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "b-prod", Namespace: "ns"},
+				Spec: turtlesv1.ClusterImportPolicySpec{
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+					Description:     "production cluster",
+					FleetWorkspace:  "prod",
+				},
+			},
+			&turtlesv1.ClusterImportPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "c-all", Namespace: "ns"},
+				Spec: turtlesv1.ClusterImportPolicySpec{
+					Description: "any cluster",
+				},
+			},
+			&turtlesv1.ClusterImportPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-dev", Namespace: "ns"},
+				Spec: turtlesv1.ClusterImportPolicySpec{
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+					Description:     "development cluster",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+		fakeClient = fake.NewClientBuilder().WithObjects(append(policies, namespace, capiCluster)...).Build()
+		r = &CAPIImportReconciler{Client: fakeClient, recorder: record.NewFakeRecorder(10)}
+	})
+
+	It("should use the first policy by name selecting the cluster", func() {
+		defaults, err := importDefaults(ctx, fakeClient, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(defaults.Description).To(Equal("production cluster"))
+
+		capiCluster.Labels = nil
+		defaults, err = importDefaults(ctx, fakeClient, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(defaults.Description).To(Equal("any cluster"))
+	})
+
+	It("should default the Rancher cluster metadata from the policy", func() {
+		defaults, err := importDefaults(ctx, fakeClient, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(r.reconcileMetadata(ctx, capiCluster, rancherCluster, defaults)).To(Succeed())
+		Expect(rancherCluster.Spec.Description).To(Equal("production cluster"))
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("prod"))
+
+		capiCluster.Annotations = map[string]string{turtlesannotations.ClusterDescriptionAnnotation: "custom"}
+		capiCluster.Labels[fleetWorkspaceLabel] = "custom"
+
+		Expect(r.reconcileMetadata(ctx, capiCluster, rancherCluster, defaults)).To(Succeed())
+		Expect(rancherCluster.Spec.Description).To(Equal("custom"))
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("custom"))
+	})
+
+	It("should only report moving existing Rancher clusters to a different Fleet workspace", func() {
+		defaults, err := importDefaults(ctx, fakeClient, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		rancherCluster.Name = rancherClusterName(capiCluster)
+		Expect(r.reconcileMetadata(ctx, capiCluster, rancherCluster, defaults)).To(Succeed())
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("prod"))
+		Expect(r.recorder.(*record.FakeRecorder).Events).To(BeEmpty())
+
+		rancherCluster.CreationTimestamp = metav1.Now()
+		capiCluster.Labels[fleetWorkspaceLabel] = "custom"
+
+		Expect(r.reconcileMetadata(ctx, capiCluster, rancherCluster, defaults)).To(Succeed())
+		Expect(r.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("FleetWorkspaceChanged")))
+	})
+
+	Context("without a policy selecting the cluster", func() {
+		BeforeEach(func() {
+			policies = policies[2:]
+		})
+
+		It("should return empty defaults", func() {
+			defaults, err := importDefaults(ctx, fakeClient, capiCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defaults).To(Equal(turtlesv1.ClusterImportPolicySpec{}))
+		})
+	})
+
+	It("should map a policy to the clusters it selects", func() {
+		mapFunc := clusterImportPolicyToCapiClusters(ctx, predicate.Funcs{}, fakeClient)
+
+		Expect(mapFunc(ctx, policies[0])).To(ConsistOf(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(capiCluster)}))
+		Expect(mapFunc(ctx, policies[2])).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Deletion policy", func() {
+	var (
+		r              *CAPIImportReconciler
+		fakeClient     client.Client
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+		objects        []client.Object
+	)
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cluster",
+				Namespace:  "ns",
+				Finalizers: []string{managementv3.CapiClusterFinalizer},
+			},
+		}
+		rancherCluster = &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "c-abcde",
+				Labels: map[string]string{
+					capiClusterOwner:          capiCluster.Name,
+					capiClusterOwnerNamespace: capiCluster.Namespace,
+					ownedLabelName:            "",
+				},
+				Finalizers: []string{managementv3.CapiClusterFinalizer},
+			},
+		}
+		objects = []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().WithObjects(append(objects, capiCluster, rancherCluster)...).Build()
+		r = &CAPIImportReconciler{Client: fakeClient, RancherClient: fakeClient, recorder: record.NewFakeRecorder(10)}
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+	})
+
+	setDeletionPolicy := func(deletionPolicy string) {
+		capiCluster.Annotations = map[string]string{turtlesannotations.DeletionPolicyAnnotation: deletionPolicy}
+	}
+
+	deleteObject := func(obj client.Object) {
+		Expect(fakeClient.Delete(ctx, obj)).To(Succeed())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+	}
+
+	It("should default to Cascade", func() {
+		Expect(r.deletionPolicy(ctx, capiCluster)).To(Equal(turtlesv1.DeletionPolicyCascade))
+	})
+
+	It("should fall back to the default on invalid values", func() {
+		setDeletionPolicy("orphan")
+
+		Expect(r.deletionPolicy(ctx, capiCluster)).To(Equal(turtlesv1.DeletionPolicyCascade))
+		Expect(r.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("InvalidDeletionPolicy")))
+	})
+
+	Context("with an import policy", func() {
+		BeforeEach(func() {
+			objects = append(objects, &turtlesv1.ClusterImportPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "ns"},
+				Spec:       turtlesv1.ClusterImportPolicySpec{DeletionPolicy: turtlesv1.DeletionPolicyDetach},
+			})
+		})
+
+		It("should let the cluster annotation take precedence", func() {
+			Expect(r.deletionPolicy(ctx, capiCluster)).To(Equal(turtlesv1.DeletionPolicyDetach))
+
+			setDeletionPolicy(string(turtlesv1.DeletionPolicyOrphan))
+			Expect(r.deletionPolicy(ctx, capiCluster)).To(Equal(turtlesv1.DeletionPolicyOrphan))
+		})
+	})
+
+	It("should delete the Rancher cluster with the CAPI cluster on Cascade", func() {
+		deleteObject(capiCluster)
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+		Expect(rancherCluster.DeletionTimestamp.IsZero()).To(BeFalse())
+	})
+
+	It("should remove the finalizer of a CAPI cluster deleted with an invalid policy", func() {
+		setDeletionPolicy("orphan")
+		Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+		deleteObject(capiCluster)
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).ToNot(Succeed())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+		Expect(rancherCluster.DeletionTimestamp.IsZero()).To(BeFalse())
+	})
+
+	It("should keep both clusters while the CAPI cluster is paused", func() {
+		capiCluster.Spec.Paused = ptr.To(true)
+		Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+		deleteObject(capiCluster)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(capiCluster)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+		Expect(rancherCluster.DeletionTimestamp.IsZero()).To(BeTrue())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+		Expect(capiCluster.Finalizers).To(ContainElement(managementv3.CapiClusterFinalizer))
+	})
+
+	It("should release the Rancher cluster when the CAPI cluster is deleted on Orphan", func() {
+		setDeletionPolicy(string(turtlesv1.DeletionPolicyOrphan))
+		Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+		deleteObject(capiCluster)
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).To(Succeed())
+		Expect(rancherCluster.DeletionTimestamp.IsZero()).To(BeTrue())
+		Expect(rancherCluster.Labels).ToNot(HaveKey(ownedLabelName))
+		Expect(rancherCluster.Labels).ToNot(HaveKey(capiClusterOwner))
+		Expect(rancherCluster.Finalizers).To(BeEmpty())
+	})
+
+	It("should stop importing the CAPI cluster when the Rancher cluster is deleted on Detach", func() {
+		setDeletionPolicy(string(turtlesv1.DeletionPolicyDetach))
+		Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+		deleteObject(rancherCluster)
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+		Expect(capiCluster.Annotations).To(HaveKeyWithValue(turtlesannotations.ClusterImportedAnnotation, "true"))
+		Expect(capiCluster.Finalizers).To(BeEmpty())
+	})
+
+	It("should remove the Rancher agent before releasing a deleted Rancher cluster on Cascade", func() {
+		remoteClient := fake.NewClientBuilder().WithObjects(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "cattle-system", Finalizers: []string{"example.com/wait"}},
+		}).Build()
+
+		r.AgentCleanup = true
+		r.remoteClientGetter = func(context.Context, string, client.Client, client.ObjectKey) (client.Client, error) {
+			return remoteClient, nil
+		}
+
+		Expect(r.recordAgentObjects(ctx, capiCluster, "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: cattle-system\n")).To(Succeed())
+		deleteObject(rancherCluster)
+
+		res, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(defaultRequeueDuration))
+		Expect(conditions.GetReason(capiCluster, turtlesv1.RancherImportedCondition)).To(Equal(turtlesv1.RancherImportAgentCleanupReason))
+		Expect(capiCluster.Annotations).ToNot(HaveKey(turtlesannotations.ClusterImportedAnnotation))
+
+		ns := &corev1.Namespace{}
+		Expect(remoteClient.Get(ctx, client.ObjectKey{Name: "cattle-system"}, ns)).To(Succeed())
+		ns.Finalizers = nil
+		Expect(remoteClient.Update(ctx, ns)).To(Succeed())
+
+		_, err = r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capiCluster.Annotations).To(HaveKeyWithValue(turtlesannotations.ClusterImportedAnnotation, "true"))
+	})
+
+	It("should import the CAPI cluster again when the Rancher cluster is deleted on Orphan", func() {
+		setDeletionPolicy(string(turtlesv1.DeletionPolicyOrphan))
+		Expect(fakeClient.Update(ctx, capiCluster)).To(Succeed())
+		deleteObject(rancherCluster)
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(capiCluster.Annotations).ToNot(HaveKey(turtlesannotations.ClusterImportedAnnotation))
+		Expect(capiCluster.Finalizers).To(ContainElement(managementv3.CapiClusterFinalizer))
+		Expect(turtlesannotations.HasPendingReimport(capiCluster)).To(BeTrue())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rancherCluster), rancherCluster)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,300 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+)
+
+const (
+	// defaultManifestDownloadTimeout is the default timeout of a single registration manifest download attempt.
+	defaultManifestDownloadTimeout = 30 * time.Second
+	// defaultManifestDownloadRetryInterval is the default wait before retrying a failed download.
+	defaultManifestDownloadRetryInterval = time.Second
+	// manifestDownloadRetriesAnnotation holds the number of failed registration manifest downloads retried
+	// in a row for the CAPI cluster.
+	manifestDownloadRetriesAnnotation = "cluster-api.cattle.io/manifest-download-retries"
+	// defaultManifestMaxSize is the default maximum size of a registration manifest.
+	defaultManifestMaxSize = 10 << 20
+	// defaultRancherServiceURL is the default URL of the in-cluster Rancher Service.
+	defaultRancherServiceURL = "https://rancher.cattle-system.svc"
+	// caBundleKey is the key of the additional CA certificates in the CA bundle Secret.
+	caBundleKey = "ca.crt"
+)
+
+// ManifestSource selects where the registration manifests are downloaded from.
+type ManifestSource string
+
+const (
+	// ManifestSourceExternal downloads the manifest from the URL reported by Rancher, based on its server-url setting.
+	ManifestSourceExternal ManifestSource = "external"
+	// ManifestSourceService downloads the manifest from the in-cluster Rancher Service, keeping the path
+	// of the URL reported by Rancher.
+	ManifestSourceService ManifestSource = "service"
+	// ManifestSourceToken downloads the manifest from the in-cluster Rancher Service, building the path
+	// from the registration token. The manifest URL doesn't need to be reported by Rancher.
+	ManifestSourceToken ManifestSource = "token"
+)
+
+// ManifestDownloadOptions configures the HTTP client downloading the registration manifests from Rancher.
+type ManifestDownloadOptions struct {
+	// Timeout is the timeout of a single download attempt.
+	Timeout time.Duration
+	// Retries is the number of times a failed download is retried, one attempt per reconcile. The first retry
+	// waits RetryInterval, and the wait doubles with every attempt. Once the retries are exhausted, the download
+	// is retried after the requeue period.
+	Retries       int
+	RetryInterval time.Duration
+	// MaxSize is the maximum size of a registration manifest in bytes.
+	MaxSize int64
+	// ProxyURL is the proxy used for the downloads. When empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables are used.
+	ProxyURL string
+	// CABundleSecret references a Secret holding additional CA certificates trusted for the downloads in its ca.crt key.
+	CABundleSecret client.ObjectKey
+	// Source selects where the manifests are downloaded from. Defaults to the URL reported by Rancher.
+	Source ManifestSource
+	// RancherServiceURL is the URL of the in-cluster Rancher Service, used by the service and token sources.
+	RancherServiceURL string
+}
+
+// manifestDownloadError is returned when the registration manifest can't be downloaded from Rancher.
+type manifestDownloadError struct {
+	err error
+	// retry is true when the download failed with a network error or a server side failure.
+	retry bool
+}
+
+func (e *manifestDownloadError) Error() string {
+	return "downloading registration manifest: " + e.err.Error()
+}
+
+func (e *manifestDownloadError) Unwrap() error {
+	return e.err
+}
+
+// manifestDownloader downloads registration manifests from Rancher.
+type manifestDownloader struct {
+	client     *http.Client
+	options    ManifestDownloadOptions
+	serviceURL *url.URL
+}
+
+// newManifestDownloader returns a downloader trusting the Rancher CA certificate when provided, or the system store
+// otherwise. The additional CA bundle is trusted in both cases.
+func newManifestDownloader(options ManifestDownloadOptions, caCert, caBundle []byte, insecureSkipVerify bool) (*manifestDownloader, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
+	}
+
+	if caCert != nil || caBundle != nil {
+		caCertPool := x509.NewCertPool()
+
+		if caCert == nil {
+			if systemPool, err := x509.SystemCertPool(); err == nil {
+				caCertPool = systemPool
+			}
+		} else if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, &manifestDownloadError{err: errors.New("failed to append CA certificate")}
+		}
+
+		if caBundle != nil && !caCertPool.AppendCertsFromPEM(caBundle) {
+			return nil, &manifestDownloadError{err: errors.New("failed to append CA bundle")}
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	proxy := http.ProxyFromEnvironment
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, &manifestDownloadError{err: fmt.Errorf("invalid proxy URL: %w", err)}
+		}
+
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	serviceURL, err := url.Parse(cmp.Or(options.RancherServiceURL, defaultRancherServiceURL))
+	if err != nil {
+		return nil, &manifestDownloadError{err: fmt.Errorf("invalid Rancher service URL: %w", err)}
+	}
+
+	options.Timeout = cmp.Or(options.Timeout, defaultManifestDownloadTimeout)
+	options.RetryInterval = cmp.Or(options.RetryInterval, defaultManifestDownloadRetryInterval)
+	options.MaxSize = cmp.Or(options.MaxSize, defaultManifestMaxSize)
+
+	return &manifestDownloader{
+		client: &http.Client{
+			Timeout: options.Timeout,
+			Transport: &http.Transport{
+				Proxy:           proxy,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		options:    options,
+		serviceURL: serviceURL,
+	}, nil
+}
+
+// manifestURL returns the URL of the registration manifest for the token, depending on the manifest source.
+// An empty URL is returned when Rancher has not generated the registration token yet.
+func (d *manifestDownloader) manifestURL(token *managementv3.ClusterRegistrationToken) (string, error) {
+	switch d.options.Source {
+	case "", ManifestSourceExternal:
+		return token.Status.ManifestURL, nil
+	case ManifestSourceService:
+		if token.Status.ManifestURL == "" {
+			return "", nil
+		}
+
+		manifestURL, err := url.Parse(token.Status.ManifestURL)
+		if err != nil {
+			return "", &manifestDownloadError{err: fmt.Errorf("invalid manifest URL: %w", err)}
+		}
+
+		serviceURL := *d.serviceURL
+		serviceURL.Path = path.Join(serviceURL.Path, manifestURL.Path)
+		serviceURL.RawQuery = manifestURL.RawQuery
+
+		return serviceURL.String(), nil
+	case ManifestSourceToken:
+		if token.Status.Token == "" {
+			return "", nil
+		}
+
+		return d.serviceURL.JoinPath("v3", "import", token.Status.Token+"_"+token.Spec.ClusterName+".yaml").String(), nil
+	default:
+		return "", &manifestDownloadError{err: fmt.Errorf("invalid manifest source %q", d.options.Source)}
+	}
+}
+
+// download returns the registration manifest. A single attempt is made, so the reconcile is not blocked
+// by an unreachable Rancher. Failures are retried with retryAfter.
+func (d *manifestDownloader) download(ctx context.Context, manifestURL string) (string, error) {
+	start := time.Now()
+	defer func() {
+		manifestDownloadDurationSeconds.Observe(time.Since(start).Seconds())
+	}()
+
+	manifest, retry, err := d.get(ctx, manifestURL)
+	if err != nil {
+		return "", &manifestDownloadError{err: err, retry: retry}
+	}
+
+	manifestSizeBytes.Observe(float64(len(manifest)))
+
+	return manifest, nil
+}
+
+// retryAfter returns when the failed registration manifest download of the CAPI cluster is retried, and records
+// the retry on the CAPI cluster. Network errors and server side failures are retried after the retry interval,
+// doubled with every retry. Other failures, and failures past the configured retries, are retried after the
+// requeue period, starting the retries again.
+func (d *manifestDownloader) retryAfter(ctx context.Context, capiCluster *clusterv1.Cluster, err *manifestDownloadError) time.Duration {
+	annotations := capiCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	retries, _ := strconv.Atoi(annotations[manifestDownloadRetriesAnnotation])
+
+	if !err.retry || retries >= d.options.Retries {
+		delete(annotations, manifestDownloadRetriesAnnotation)
+		capiCluster.SetAnnotations(annotations)
+
+		return defaultRequeueDuration
+	}
+
+	interval := d.options.RetryInterval << retries
+
+	log.FromContext(ctx).V(2).Info("Registration manifest download failed, retrying", "error", err.Error(), "after", interval)
+
+	annotations[manifestDownloadRetriesAnnotation] = strconv.Itoa(retries + 1)
+	capiCluster.SetAnnotations(annotations)
+
+	return interval
+}
+
+// get downloads the registration manifest once. It returns true when the download can be retried.
+func (d *manifestDownloader) get(ctx context.Context, manifestURL string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+		return "", retry, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, d.options.MaxSize+1))
+	if err != nil {
+		return "", true, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	if int64(len(data)) > d.options.MaxSize {
+		return "", false, fmt.Errorf("manifest exceeds the maximum size of %d bytes", d.options.MaxSize)
+	}
+
+	return string(data), false, nil
+}
+
+// manifestDownloader returns the downloader for the registration manifests, trusting the Rancher CA certificate
+// and the additional CA bundle.
+func (r *CAPIImportReconciler) manifestDownloader(ctx context.Context, caCert []byte) (*manifestDownloader, error) {
+	var caBundle []byte
+
+	if key := r.ManifestDownload.CABundleSecret; key.Name != "" {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, key, secret); err != nil {
+			return nil, &manifestDownloadError{err: fmt.Errorf("getting CA bundle secret %s: %w", key, err)}
+		}
+
+		if caBundle = secret.Data[caBundleKey]; len(caBundle) == 0 {
+			return nil, &manifestDownloadError{err: fmt.Errorf("CA bundle secret %s has no %s key", key, caBundleKey)}
+		}
+	}
+
+	return newManifestDownloader(r.ManifestDownload, caCert, caBundle, r.InsecureSkipVerify)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+)
+
+var _ = Describe("Registration manifest download", func() {
+	const manifest = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: cattle-system\n"
+
+	var (
+		options  ManifestDownloadOptions
+		requests atomic.Int32
+	)
+
+	BeforeEach(func() {
+		options = ManifestDownloadOptions{Retries: 2, RetryInterval: time.Millisecond}
+		requests.Store(0)
+	})
+
+	serve := func(statuses ...int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempt := int(requests.Add(1)) - 1
+			if attempt < len(statuses) {
+				w.WriteHeader(statuses[attempt])
+				return
+			}
+
+			w.Write([]byte(manifest))
+		}))
+		DeferCleanup(server.Close)
+
+		return server
+	}
+
+	download := func(manifestURL string) (string, error) {
+		downloader, err := newManifestDownloader(options, nil, nil, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		return downloader.download(ctx, manifestURL)
+	}
+
+	It("should make a single attempt", func() {
+		server := serve(http.StatusServiceUnavailable)
+
+		_, err := download(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("503")))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+
+		var downloadErr *manifestDownloadError
+		Expect(errors.As(err, &downloadErr)).To(BeTrue())
+		Expect(downloadErr.retry).To(BeTrue())
+
+		Expect(download(server.URL)).To(Equal(manifest))
+	})
+
+	It("should not retry client errors", func() {
+		server := serve(http.StatusNotFound)
+
+		_, err := download(server.URL)
+
+		var downloadErr *manifestDownloadError
+		Expect(errors.As(err, &downloadErr)).To(BeTrue())
+		Expect(downloadErr.retry).To(BeFalse())
+	})
+
+	It("should back off the retries of the CAPI cluster up to the configured retries", func() {
+		options.RetryInterval = time.Second
+
+		downloader, err := newManifestDownloader(options, nil, nil, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		capiCluster := &clusterv1.Cluster{}
+		downloadErr := &manifestDownloadError{err: errors.New("unavailable"), retry: true}
+
+		Expect(downloader.retryAfter(ctx, capiCluster, downloadErr)).To(Equal(time.Second))
+		Expect(downloader.retryAfter(ctx, capiCluster, downloadErr)).To(Equal(2 * time.Second))
+		Expect(capiCluster.Annotations).To(HaveKeyWithValue(manifestDownloadRetriesAnnotation, "2"))
+
+		Expect(downloader.retryAfter(ctx, capiCluster, downloadErr)).To(Equal(defaultRequeueDuration))
+		Expect(capiCluster.Annotations).ToNot(HaveKey(manifestDownloadRetriesAnnotation))
+
+		Expect(downloader.retryAfter(ctx, capiCluster, downloadErr)).To(Equal(time.Second))
+		Expect(downloader.retryAfter(ctx, capiCluster, &manifestDownloadError{err: errors.New("not found")})).
+			To(Equal(defaultRequeueDuration))
+	})
+
+	It("should reject manifests over the maximum size", func() {
+		server := serve()
+		options.MaxSize = int64(len(manifest) - 1)
+
+		_, err := download(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("maximum size")))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should download through the configured proxy", func() {
+		proxy := serve()
+		options.ProxyURL = proxy.URL
+
+		Expect(download("http://rancher.invalid/v3/import/token.yaml")).To(Equal(manifest))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	Context("with a private CA", func() {
+		var (
+			server *httptest.Server
+			r      *CAPIImportReconciler
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte(manifest))
+			}))
+			DeferCleanup(server.Close)
+
+			caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: "rancher-turtles-system"},
+				Data:       map[string][]byte{caBundleKey: caBundle},
+			}
+
+			r = &CAPIImportReconciler{Client: fake.NewClientBuilder().WithObjects(secret).Build()}
+		})
+
+		It("should not trust the server by default", func() {
+			downloader, err := r.manifestDownloader(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = downloader.download(ctx, server.URL)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should trust the CA bundle from the secret", func() {
+			r.ManifestDownload.CABundleSecret = client.ObjectKey{Name: "ca-bundle", Namespace: "rancher-turtles-system"}
+
+			downloader, err := r.manifestDownloader(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(downloader.download(ctx, server.URL)).To(Equal(manifest))
+		})
+
+		It("should report a missing CA bundle secret as a download error", func() {
+			r.ManifestDownload.CABundleSecret = client.ObjectKey{Name: "missing", Namespace: "rancher-turtles-system"}
+
+			_, err := r.manifestDownloader(ctx, nil)
+
+			var downloadErr *manifestDownloadError
+			Expect(errors.As(err, &downloadErr)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("missing")))
+		})
+	})
+
+	Context("with an in-cluster manifest source", func() {
+		var token *managementv3.ClusterRegistrationToken
+
+		BeforeEach(func() {
+			options.RancherServiceURL = "https://rancher.cattle-system.svc"
+			token = &managementv3.ClusterRegistrationToken{
+				ObjectMeta: metav1.ObjectMeta{Name: "c-abcde", Namespace: "c-abcde"},
+				Spec:       managementv3.ClusterRegistrationTokenSpec{ClusterName: "c-abcde"},
+				Status: managementv3.ClusterRegistrationTokenStatus{
+					ManifestURL: "https://rancher.example.com/v3/import/secret_c-abcde.yaml",
+					Token:       "secret",
+				},
+			}
+		})
+
+		manifestURL := func() (string, error) {
+			downloader, err := newManifestDownloader(options, nil, nil, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			return downloader.manifestURL(token)
+		}
+
+		It("should use the URL reported by Rancher by default", func() {
+			Expect(manifestURL()).To(Equal(token.Status.ManifestURL))
+		})
+
+		It("should rewrite the host to the Rancher service", func() {
+			options.Source = ManifestSourceService
+			Expect(manifestURL()).To(Equal("https://rancher.cattle-system.svc/v3/import/secret_c-abcde.yaml"))
+
+			token.Status.ManifestURL = ""
+			Expect(manifestURL()).To(BeEmpty())
+		})
+
+		It("should build the URL from the registration token", func() {
+			options.Source = ManifestSourceToken
+			token.Status.ManifestURL = ""
+			Expect(manifestURL()).To(Equal("https://rancher.cattle-system.svc/v3/import/secret_c-abcde.yaml"))
+
+			token.Status.Token = ""
+			Expect(manifestURL()).To(BeEmpty())
+		})
+
+		It("should download the manifest from the Rancher service", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/v3/import/secret_c-abcde.yaml" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Write([]byte(manifest))
+			}))
+			DeferCleanup(server.Close)
+
+			options.Source = ManifestSourceToken
+			options.RancherServiceURL = server.URL
+			token.Status.ManifestURL = ""
+
+			downloader, err := newManifestDownloader(options, nil, nil, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			cl := fake.NewClientBuilder().WithObjects(token).WithStatusSubresource(token).Build()
+			Expect(getClusterRegistrationManifest(ctx, "c-abcde", "c-abcde", cl, downloader)).To(Equal(manifest))
+		})
+	})
+})
@@ -0,0 +1,176 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+const (
+	// lastSyncedMetadataAnnotation holds the metadata last propagated from the CAPI cluster to the Rancher cluster.
+	lastSyncedMetadataAnnotation = "cluster-api.cattle.io/last-synced-metadata"
+
+	defaultClusterDescription = "CAPI cluster imported to Rancher"
+)
+
+// syncedMetadata is the set of Rancher cluster fields owned by Turtles and propagated from the CAPI cluster.
+type syncedMetadata struct {
+	DisplayName        string            `json:"displayName,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	FleetWorkspaceName string            `json:"fleetWorkspaceName,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	Annotations        map[string]string `json:"annotations,omitempty"`
+}
+
+// desiredMetadata collects the Rancher cluster metadata from the CAPI cluster. Only labels and annotations
+// matching the allow-lists are propagated. An allow-list entry matches either the exact key or,
+// when it ends with '/', every key with that prefix.
+func desiredMetadata(capiCluster *clusterv1.Cluster, allowedLabels, allowedAnnotations []string) syncedMetadata {
+	description := capiCluster.Annotations[turtlesannotations.ClusterDescriptionAnnotation]
+	if description == "" {
+		description = defaultClusterDescription
+	}
+
+	return syncedMetadata{
+		DisplayName: capiCluster.Name,
+		Description: description,
+		Labels:      filterMetadata(capiCluster.Labels, allowedLabels),
+		Annotations: filterMetadata(capiCluster.Annotations, allowedAnnotations),
+	}
+}
+
+func filterMetadata(in map[string]string, allowed []string) map[string]string {
+	out := map[string]string{}
+
+	for key, value := range in {
+		if isReservedMetadataKey(key) {
+			continue
+		}
+
+		if slices.ContainsFunc(allowed, func(entry string) bool {
+			return key == entry || (strings.HasSuffix(entry, "/") && strings.HasPrefix(key, entry))
+		}) {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// isReservedMetadataKey returns true for keys Turtles manages on the Rancher cluster by itself.
+func isReservedMetadataKey(key string) bool {
+	switch key {
+	case capiClusterOwner, capiClusterOwnerNamespace, ownedLabelName, lastSyncedMetadataAnnotation, lastAgentReapplyAnnotation,
+		manifestDownloadRetriesAnnotation:
+		return true
+	default:
+		return false
+	}
+}
+
+// syncMetadata applies the desired metadata to the Rancher cluster. Keys propagated previously but no longer
+// present on the CAPI cluster are removed. It returns the list of fields which were changed directly
+// on the Rancher cluster since the last sync, and are going to be overwritten.
+func syncMetadata(rancherCluster *managementv3.Cluster, desired syncedMetadata) []string {
+	var conflicts []string
+
+	labels := rancherCluster.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	annotations := rancherCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	last := &syncedMetadata{}
+	if err := json.Unmarshal([]byte(annotations[lastSyncedMetadataAnnotation]), last); err != nil {
+		// Nothing was synced before, there is nothing to compare against.
+		last = nil
+	}
+
+	if last != nil {
+		if rancherCluster.Spec.DisplayName != last.DisplayName {
+			conflicts = append(conflicts, "spec.displayName")
+		}
+
+		if rancherCluster.Spec.Description != last.Description {
+			conflicts = append(conflicts, "spec.description")
+		}
+
+		if last.FleetWorkspaceName != "" && rancherCluster.Spec.FleetWorkspaceName != last.FleetWorkspaceName {
+			conflicts = append(conflicts, "spec.fleetWorkspaceName")
+		}
+
+		conflicts = append(conflicts, metadataConflicts("label", labels, last.Labels)...)
+		conflicts = append(conflicts, metadataConflicts("annotation", annotations, last.Annotations)...)
+
+		for key := range last.Labels {
+			delete(labels, key)
+		}
+
+		for key := range last.Annotations {
+			delete(annotations, key)
+		}
+	}
+
+	maps.Copy(labels, desired.Labels)
+	maps.Copy(annotations, desired.Annotations)
+
+	// Marshalling a struct of strings and string maps can't fail.
+	synced, _ := json.Marshal(desired) //nolint:errchkjson
+	annotations[lastSyncedMetadataAnnotation] = string(synced)
+
+	rancherCluster.Spec.DisplayName = desired.DisplayName
+	rancherCluster.Spec.Description = desired.Description
+
+	// The Fleet workspace is only managed once selected on the CAPI cluster. When the selection is removed,
+	// the cluster is moved back to the default workspace.
+	switch {
+	case desired.FleetWorkspaceName != "":
+		rancherCluster.Spec.FleetWorkspaceName = desired.FleetWorkspaceName
+	case last != nil && last.FleetWorkspaceName != "":
+		rancherCluster.Spec.FleetWorkspaceName = defaultFleetWorkspace
+	}
+	rancherCluster.SetLabels(labels)
+	rancherCluster.SetAnnotations(annotations)
+
+	return conflicts
+}
+
+func metadataConflicts(kind string, current, last map[string]string) []string {
+	conflicts := []string{}
+
+	for key, value := range last {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			conflicts = append(conflicts, kind+" "+key)
+		}
+	}
+
+	slices.Sort(conflicts)
+
+	return conflicts
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Rancher cluster metadata sync", func() {
+	var (
+		capiCluster    *clusterv1.Cluster
+		rancherCluster *managementv3.Cluster
+	)
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "ns",
+				Labels: map[string]string{
+					"team":                "a",
+					"example.com/env":     "prod",
+					"not-propagated":      "value",
+					ownedLabelName:        "",
+					"other.example.com/x": "y",
+				},
+				Annotations: map[string]string{
+					turtlesannotations.ClusterDescriptionAnnotation: "custom description",
+					"example.com/owner":                             "someone",
+				},
+			},
+		}
+
+		rancherCluster = &managementv3.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "c-abcde",
+				Labels: map[string]string{
+					ownedLabelName: "",
+				},
+			},
+		}
+	})
+
+	It("should default the description", func() {
+		capiCluster.Annotations = nil
+
+		desired := desiredMetadata(capiCluster, nil, nil)
+		Expect(desired.DisplayName).To(Equal("cluster"))
+		Expect(desired.Description).To(Equal(defaultClusterDescription))
+		Expect(desired.Labels).To(BeEmpty())
+		Expect(desired.Annotations).To(BeEmpty())
+	})
+
+	It("should only propagate allow-listed keys", func() {
+		desired := desiredMetadata(capiCluster, []string{"team", "example.com/", ownedLabelName}, []string{"example.com/"})
+		Expect(desired.Labels).To(Equal(map[string]string{
+			"team":            "a",
+			"example.com/env": "prod",
+		}))
+		Expect(desired.Annotations).To(Equal(map[string]string{
+			"example.com/owner": "someone",
+		}))
+	})
+
+	It("should apply metadata without conflicts on first sync", func() {
+		conflicts := syncMetadata(rancherCluster, desiredMetadata(capiCluster, []string{"team"}, nil))
+		Expect(conflicts).To(BeEmpty())
+
+		Expect(rancherCluster.Spec.DisplayName).To(Equal("cluster"))
+		Expect(rancherCluster.Spec.Description).To(Equal("custom description"))
+		Expect(rancherCluster.Labels).To(HaveKeyWithValue("team", "a"))
+		Expect(rancherCluster.Labels).To(HaveKey(ownedLabelName))
+		Expect(rancherCluster.Annotations).To(HaveKey(lastSyncedMetadataAnnotation))
+	})
+
+	It("should propagate updates and remove keys no longer present", func() {
+		syncMetadata(rancherCluster, desiredMetadata(capiCluster, []string{"team"}, nil))
+
+		delete(capiCluster.Labels, "team")
+		capiCluster.Annotations[turtlesannotations.ClusterDescriptionAnnotation] = "updated"
+
+		conflicts := syncMetadata(rancherCluster, desiredMetadata(capiCluster, []string{"team"}, nil))
+		Expect(conflicts).To(BeEmpty())
+		Expect(rancherCluster.Spec.Description).To(Equal("updated"))
+		Expect(rancherCluster.Labels).ToNot(HaveKey("team"))
+	})
+
+	It("should detect and overwrite changes made on the Rancher cluster", func() {
+		syncMetadata(rancherCluster, desiredMetadata(capiCluster, []string{"team"}, nil))
+
+		rancherCluster.Spec.Description = "edited in rancher"
+		rancherCluster.Labels["team"] = "b"
+
+		conflicts := syncMetadata(rancherCluster, desiredMetadata(capiCluster, []string{"team"}, nil))
+		Expect(conflicts).To(ConsistOf("spec.description", "label team"))
+		Expect(rancherCluster.Spec.Description).To(Equal("custom description"))
+		Expect(rancherCluster.Labels).To(HaveKeyWithValue("team", "a"))
+	})
+
+	It("should leave the Fleet workspace unmanaged until one is selected", func() {
+		rancherCluster.Spec.FleetWorkspaceName = "set-in-rancher"
+
+		conflicts := syncMetadata(rancherCluster, desiredMetadata(capiCluster, nil, nil))
+		Expect(conflicts).To(BeEmpty())
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("set-in-rancher"))
+	})
+
+	It("should move the Rancher cluster between Fleet workspaces", func() {
+		desired := desiredMetadata(capiCluster, nil, nil)
+		desired.FleetWorkspaceName = "team-a"
+
+		Expect(syncMetadata(rancherCluster, desired)).To(BeEmpty())
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("team-a"))
+
+		desired.FleetWorkspaceName = "team-b"
+		Expect(syncMetadata(rancherCluster, desired)).To(BeEmpty())
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("team-b"))
+
+		rancherCluster.Spec.FleetWorkspaceName = "edited-in-rancher"
+		Expect(syncMetadata(rancherCluster, desired)).To(ConsistOf("spec.fleetWorkspaceName"))
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal("team-b"))
+
+		desired.FleetWorkspaceName = ""
+		Expect(syncMetadata(rancherCluster, desired)).To(BeEmpty())
+		Expect(rancherCluster.Spec.FleetWorkspaceName).To(Equal(defaultFleetWorkspace))
+	})
+})
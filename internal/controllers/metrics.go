@@ -0,0 +1,182 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+)
+
+const metricsNamespace = "turtles"
+
+// Reasons used to label import failures.
+const (
+	importFailureClusterCreate      = "cluster_create"
+	importFailureCACert             = "ca_cert"
+	importFailureRegistrationToken  = "registration_token"
+	importFailureManifestDownload   = "manifest_download"
+	importFailureManifestAlter      = "manifest_alter"
+	importFailureRemoteClient       = "remote_client"
+	importFailureManifestValidation = "manifest_validation"
+	importFailureManifestApply      = "manifest_apply"
+	importFailureResourceSet        = "resource_set"
+	importFailureFleetMigration     = "fleet_migration"
+	importFailureClusterAccess      = "cluster_access"
+)
+
+// Outcomes of the fleet agent namespace migration.
+const (
+	fleetMigrationMigrated = "migrated"
+	fleetMigrationPending  = "pending"
+	fleetMigrationError    = "error"
+)
+
+// States of the Rancher clusters managed by Turtles.
+const (
+	rancherClusterStateReady    = "ready"
+	rancherClusterStatePending  = "pending"
+	rancherClusterStateDeleting = "deleting"
+)
+
+var (
+	importAttemptsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_import_attempts_total",
+		Help:      "Total number of attempts to apply the Rancher import manifest on a CAPI cluster.",
+	})
+
+	importFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_import_failures_total",
+		Help:      "Total number of failed CAPI cluster imports, by reason.",
+	}, []string{"reason"})
+
+	reimportsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_reimports_total",
+		Help:      "Total number of import manifest re-applications for clusters whose Rancher agent was disconnected.",
+	})
+
+	importDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_import_duration_seconds",
+		Help:      "Time from the CAPI cluster control plane becoming available to the Rancher cluster becoming Ready.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 12),
+	})
+
+	manifestDownloadDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "import_manifest_download_duration_seconds",
+		Help:      "Latency of the Rancher registration manifest download.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	manifestSizeBytes = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "import_manifest_size_bytes",
+		Help:      "Size of the downloaded Rancher registration manifest.",
+		Buckets:   prometheus.ExponentialBuckets(1024, 2, 10),
+	})
+
+	fleetNamespaceMigrationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "fleet_namespace_migrations_total",
+		Help:      "Total number of fleet agent namespace migration checks on downstream clusters, by result.",
+	}, []string{"result"})
+
+	rancherClustersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "rancher_clusters"),
+		"Number of Rancher clusters managed by Turtles, by state.",
+		[]string{"state"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		importAttemptsTotal,
+		importFailuresTotal,
+		reimportsTotal,
+		importDurationSeconds,
+		manifestDownloadDurationSeconds,
+		manifestSizeBytes,
+		fleetNamespaceMigrationsTotal,
+	)
+}
+
+// rancherClusterCollector reports the number of Rancher clusters owned by Turtles on every scrape.
+type rancherClusterCollector struct {
+	client client.Client
+}
+
+// registerRancherClusterCollector registers the collector for Rancher clusters read through the given client.
+func registerRancherClusterCollector(cl client.Client) error {
+	if err := metrics.Registry.Register(&rancherClusterCollector{client: cl}); err != nil {
+		if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *rancherClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rancherClustersDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *rancherClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	rancherClusters := &managementv3.ClusterList{}
+	if err := c.client.List(context.Background(), rancherClusters, client.HasLabels{ownedLabelName}); err != nil {
+		ch <- prometheus.NewInvalidMetric(rancherClustersDesc, err)
+		return
+	}
+
+	for state, count := range countRancherClustersByState(rancherClusters.Items) {
+		ch <- prometheus.MustNewConstMetric(rancherClustersDesc, prometheus.GaugeValue, float64(count), state)
+	}
+}
+
+func countRancherClustersByState(rancherClusters []managementv3.Cluster) map[string]int {
+	counts := map[string]int{
+		rancherClusterStateReady:    0,
+		rancherClusterStatePending:  0,
+		rancherClusterStateDeleting: 0,
+	}
+
+	for _, rancherCluster := range rancherClusters {
+		switch {
+		case !rancherCluster.DeletionTimestamp.IsZero():
+			counts[rancherClusterStateDeleting]++
+		case conditions.IsTrue(&rancherCluster, managementv3.ClusterConditionReady):
+			counts[rancherClusterStateReady]++
+		default:
+			counts[rancherClusterStatePending]++
+		}
+	}
+
+	return counts
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Import metrics", func() {
+	It("should count managed Rancher clusters by state", func() {
+		now := metav1.Now()
+
+		cl := fake.NewClientBuilder().WithObjects(
+			&managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Name:   "c-ready",
+				Labels: map[string]string{ownedLabelName: ""},
+			}, Status: managementv3.ClusterStatus{Conditions: []metav1.Condition{{
+				Type:   managementv3.ClusterConditionReady,
+				Status: metav1.ConditionTrue,
+			}}}},
+			&managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Name:   "c-pending",
+				Labels: map[string]string{ownedLabelName: ""},
+			}},
+			&managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Name:              "c-deleting",
+				Labels:            map[string]string{ownedLabelName: ""},
+				DeletionTimestamp: &now,
+				Finalizers:        []string{managementv3.CapiClusterFinalizer},
+			}},
+			&managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Name: "c-not-owned",
+			}},
+		).Build()
+
+		expected := `
+# HELP turtles_rancher_clusters Number of Rancher clusters managed by Turtles, by state.
+# TYPE turtles_rancher_clusters gauge
+turtles_rancher_clusters{state="deleting"} 1
+turtles_rancher_clusters{state="pending"} 1
+turtles_rancher_clusters{state="ready"} 1
+`
+		Expect(testutil.CollectAndCompare(&rancherClusterCollector{client: cl}, strings.NewReader(expected))).To(Succeed())
+	})
+
+	It("should observe the manifest download latency and size", func() {
+		manifest := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: cattle-system\n"
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(manifest))
+		}))
+		defer server.Close()
+
+		sizeBefore := histogramSnapshot(manifestSizeBytes)
+		durationBefore := histogramSnapshot(manifestDownloadDurationSeconds)
+
+		downloader, err := newManifestDownloader(ManifestDownloadOptions{}, nil, nil, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = downloader.download(ctx, server.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		size := histogramSnapshot(manifestSizeBytes)
+		Expect(size.GetSampleCount()).To(Equal(sizeBefore.GetSampleCount() + 1))
+		Expect(size.GetSampleSum()).To(Equal(sizeBefore.GetSampleSum() + float64(len(manifest))))
+		Expect(histogramSnapshot(manifestDownloadDurationSeconds).GetSampleCount()).To(Equal(durationBefore.GetSampleCount() + 1))
+	})
+
+	DescribeTable("should only observe the import duration of imports in progress",
+		func(reason string, observed bool) {
+			capiCluster := &clusterv1.Cluster{}
+			conditions.Set(capiCluster, metav1.Condition{
+				Type:               clusterv1.ClusterControlPlaneAvailableCondition,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			})
+
+			if reason != "" {
+				conditions.Set(capiCluster, metav1.Condition{Type: turtlesv1.RancherImportedCondition, Reason: reason})
+			}
+
+			before := histogramSnapshot(importDurationSeconds).GetSampleCount()
+
+			(&CAPIImportReconciler{}).observeImportDuration(capiCluster)
+
+			Expect(histogramSnapshot(importDurationSeconds).GetSampleCount() > before).To(Equal(observed))
+		},
+		Entry("a manifest applied", turtlesv1.RancherImportManifestAppliedReason, true),
+		Entry("a manifest delivered with a ClusterResourceSet", turtlesv1.RancherImportManifestDeliveredReason, true),
+		Entry("a cluster imported before the condition was reported", "", false),
+		Entry("an imported cluster", turtlesv1.RancherImportFleetMigratedReason, false),
+		Entry("a failed import", turtlesv1.RancherImportFailedReason, false),
+		Entry("a reconnecting agent", turtlesv1.RancherImportAgentDisconnectedReason, false),
+	)
+})
+
+func histogramSnapshot(h prometheus.Histogram) *dto.Histogram {
+	m := &dto.Metric{}
+	Expect(h.Write(m)).To(Succeed())
+
+	return m.GetHistogram()
+}
@@ -55,12 +55,16 @@ const (
 )
 
 // OperatorReconciler is a mapping wrapper for CAPIProvider -> operator provider resources.
-type OperatorReconciler struct{}
+type OperatorReconciler struct {
+	// RancherClient is used to access Rancher settings. Defaults to the manager client.
+	RancherClient client.Client
+}
 
 // SetupWithManager is a mapping wrapper for CAPIProvider -> operator provider resources.
 func (r *OperatorReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options ctr.Options) error {
 	if err := (&CAPIProviderReconciler{
-		Client: mgr.GetClient(),
+		Client:        mgr.GetClient(),
+		RancherClient: cmp.Or(r.RancherClient, mgr.GetClient()),
 		GenericProviderReconciler: controller.GenericProviderReconciler{
 			Provider:     &turtlesv1.CAPIProvider{},
 			ProviderList: &turtlesv1.CAPIProviderList{},
@@ -96,6 +100,8 @@ func (r *OperatorReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Mana
 type CAPIProviderReconciler struct {
 	controller.GenericProviderReconciler
 	client.Client
+
+	RancherClient client.Client
 }
 
 // BuildWithManager builds the CAPIProviderReconciler.
@@ -121,6 +127,11 @@ func (r *CAPIProviderReconciler) BuildWithManager(ctx context.Context, mgr ctrl.
 		handler.EnqueueRequestsFromMapFunc(newCoreProviderToProviderFuncMapForProviderList(mgr.GetClient())),
 	)
 
+	builder = builder.Watches(
+		&turtlesv1.CAPIProvider{},
+		handler.EnqueueRequestsFromMapFunc(newProviderToDependentsFuncMapForProviderList(mgr.GetClient())),
+	)
+
 	customAlterFuncs := []repository.ComponentsAlterFn{}
 
 	customAlterFuncs = append(customAlterFuncs, provider.AddClusterIndexedLabelFn)
@@ -141,6 +152,8 @@ func (r *CAPIProviderReconciler) BuildWithManager(ctx context.Context, mgr ctrl.
 	r.ReconcilePhases = []controller.PhaseFn{
 		r.waitForClusterctlConfigUpdate,
 		r.setProviderSpec,
+		r.rollbackTimedOutUpgrade,
+		r.checkDependencies,
 		r.syncSecrets,
 	}
 
@@ -158,15 +171,19 @@ func (r *CAPIProviderReconciler) BuildWithManager(ctx context.Context, mgr ctrl.
 		rec.Load,
 		rec.Fetch,
 		rec.Store,
+		r.checkContractCompatibility,
 		rec.Upgrade,
 		rec.Install,
 		rec.ReportStatus,
 		r.setConditions,
+		r.checkUpgradeHealth,
+		r.requeueForMaintenanceWindow,
 		rec.Finalize,
 	}...)
 
 	r.DeletePhases = []controller.PhaseFn{
 		r.waitForClusterctlConfigUpdate,
+		r.waitForDependents,
 		rec.Delete,
 	}
 
@@ -213,8 +230,9 @@ func newSecretToProviderFuncMapForProviderList(cl client.Client) handler.MapFunc
 }
 
 // newCoreProviderToProviderFuncMapForProviderList maps a ready CoreProvider object to all other provider objects.
-// It lists all the providers and if its PreflightCheckCondition is not True, this object will be added to the resulting request.
-// This means that notifications will only be sent to those objects that have not pass PreflightCheck.
+// It lists all the providers and if its PreflightCheckCondition is not True, or its ContractCompatibleCondition is False,
+// this object will be added to the resulting request. This means that notifications will only be sent to those objects
+// that have not pass PreflightCheck, or were blocked by the contract of the previous core provider.
 func newCoreProviderToProviderFuncMapForProviderList(cl client.Client) handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		log := ctrl.LoggerFrom(ctx).WithValues("provider", map[string]string{"name": obj.GetName(), "namespace": obj.GetNamespace()})
@@ -248,8 +266,9 @@ func newCoreProviderToProviderFuncMapForProviderList(cl client.Client) handler.M
 				continue
 			}
 
-			if !conditions.IsTrue(&provider, operatorv1.PreflightCheckCondition) {
-				// Raise secondary events for the providers that fail PreflightCheck.
+			if !conditions.IsTrue(&provider, operatorv1.PreflightCheckCondition) ||
+				conditions.IsFalse(&provider, turtlesv1.ContractCompatibleCondition) {
+				// Raise secondary events for the providers that fail PreflightCheck or the contract check.
 				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&provider)})
 			}
 		}
@@ -258,6 +277,37 @@ func newCoreProviderToProviderFuncMapForProviderList(cl client.Client) handler.M
 	}
 }
 
+// newProviderToDependentsFuncMapForProviderList maps a provider object to all the providers depending on it,
+// so they are installed, upgraded or deleted once the provider changes.
+func newProviderToDependentsFuncMapForProviderList(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx).WithValues("provider", map[string]string{"name": obj.GetName(), "namespace": obj.GetNamespace()})
+
+		dependency, ok := obj.(*turtlesv1.CAPIProvider)
+		if !ok {
+			log.Error(fmt.Errorf("expected a %T but got a %T", turtlesv1.CAPIProvider{}, obj), "unable to cast object")
+			return nil
+		}
+
+		providerList := &turtlesv1.CAPIProviderList{}
+		if err := cl.List(ctx, providerList); err != nil {
+			log.Error(err, "failed to list providers")
+
+			return nil
+		}
+
+		var requests []reconcile.Request
+
+		for _, p := range providerList.Items {
+			if provider.DependsOn(&p, dependency) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+			}
+		}
+
+		return requests
+	}
+}
+
 // Reconcile wraps the upstream Reconcile method.
 func (r *CAPIProviderReconciler) Reconcile(ctx context.Context, provider *turtlesv1.CAPIProvider) (_ reconcile.Result, reterr error) {
 	if !controllerutil.ContainsFinalizer(provider, operatorv1.ProviderFinalizer) && provider.DeletionTimestamp.IsZero() {
@@ -278,7 +328,7 @@ func (r *CAPIProviderReconciler) Reconcile(ctx context.Context, provider *turtle
 
 func (r *CAPIProviderReconciler) setProviderSpec(ctx context.Context) (*controller.Result, error) {
 	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
-		return &controller.Result{}, provider.SetProviderSpec(ctx, r.Client, capiProvider)
+		return &controller.Result{}, provider.SetProviderSpec(ctx, r.Client, r.rancherClient(), capiProvider)
 	}
 
 	return &controller.Result{}, nil
@@ -305,6 +355,54 @@ func (r *CAPIProviderReconciler) setConditions(_ context.Context) (*controller.R
 	return &controller.Result{}, nil
 }
 
+func (r *CAPIProviderReconciler) checkDependencies(ctx context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.CheckDependencies(ctx, r.Client, capiProvider)
+	}
+
+	return &controller.Result{}, nil
+}
+
+func (r *CAPIProviderReconciler) waitForDependents(ctx context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.WaitForDependents(ctx, r.Client, capiProvider)
+	}
+
+	return &controller.Result{}, nil
+}
+
+func (r *CAPIProviderReconciler) checkContractCompatibility(ctx context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.CheckContractCompatibility(ctx, r.Client, capiProvider)
+	}
+
+	return &controller.Result{}, nil
+}
+
+func (r *CAPIProviderReconciler) checkUpgradeHealth(ctx context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.CheckUpgradeHealth(ctx, r.Client, capiProvider)
+	}
+
+	return &controller.Result{}, nil
+}
+
+func (r *CAPIProviderReconciler) rollbackTimedOutUpgrade(ctx context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.RollbackTimedOutUpgrade(ctx, r.Client, capiProvider)
+	}
+
+	return &controller.Result{}, nil
+}
+
+func (r *CAPIProviderReconciler) requeueForMaintenanceWindow(_ context.Context) (*controller.Result, error) {
+	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
+		return provider.RequeueForMaintenanceWindow(capiProvider), nil
+	}
+
+	return &controller.Result{}, nil
+}
+
 func (r *CAPIProviderReconciler) cleanupCertManagerResources(ctx context.Context) (*controller.Result, error) {
 	if capiProvider, ok := r.Provider.(*turtlesv1.CAPIProvider); ok {
 		return provider.CleanupCertManagerResources(ctx, r.Client, capiProvider)
@@ -420,7 +518,7 @@ func (r *CAPIProviderReconciler) getGenericProvider(ctx context.Context, p confi
 
 	pr := list.Items[0]
 	// We need to default provider spec here, otherwise vesion and other required fields may be empty
-	if err := provider.SetProviderSpec(ctx, r.Client, &pr); err != nil {
+	if err := provider.SetProviderSpec(ctx, r.Client, r.rancherClient(), &pr); err != nil {
 		return nil, err
 	}
 
@@ -506,5 +604,10 @@ func (r *CAPIProviderReconciler) syncSecrets(ctx context.Context) (*controller.R
 }
 
 func (r *CAPIProviderReconciler) waitForClusterctlConfigUpdate(ctx context.Context) (*controller.Result, error) {
-	return provider.WaitForClusterctlConfigUpdate(ctx, r.Client)
+	return provider.WaitForClusterctlConfigUpdate(ctx, r.Client, r.rancherClient())
+}
+
+// rancherClient returns the client for the Rancher Manager cluster, falling back to the local client.
+func (r *CAPIProviderReconciler) rancherClient() client.Client {
+	return cmp.Or(r.RancherClient, r.Client)
 }
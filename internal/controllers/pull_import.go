@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+const (
+	// importResourceSetLabel is set on the CAPI cluster to be selected by its import ClusterResourceSet.
+	importResourceSetLabel = "cluster-api.cattle.io/import-resource-set"
+	// importResourceSetSuffix is appended to the CAPI cluster name to name the import ClusterResourceSet and its Secret.
+	importResourceSetSuffix = "-rancher-import"
+	// importResourceSetKey is the key of the import manifest in the ClusterResourceSet Secret.
+	importResourceSetKey = "import.yaml"
+)
+
+// importMode returns how the import manifest is delivered to the CAPI cluster. The cluster annotation takes precedence
+// over the import policy, and the Push mode is used by default.
+func (r *CAPIImportReconciler) importMode(capiCluster *clusterv1.Cluster, defaults turtlesv1.ClusterImportPolicySpec) (turtlesv1.ImportMode, error) {
+	mode := cmp.Or(
+		turtlesv1.ImportMode(capiCluster.GetAnnotations()[turtlesannotations.ImportModeAnnotation]),
+		defaults.Agent.Mode,
+		turtlesv1.ImportModePush,
+	)
+
+	switch mode {
+	case turtlesv1.ImportModePush, turtlesv1.ImportModePull:
+		return mode, nil
+	default:
+		r.recorder.Eventf(capiCluster, corev1.EventTypeWarning, "InvalidImportMode",
+			"Invalid import mode %q, expected one of Push, Pull", mode)
+
+		return "", fmt.Errorf("invalid import mode %q", mode)
+	}
+}
+
+// reconcileImportResourceSet delivers the import manifest to the CAPI cluster with a ClusterResourceSet,
+// selecting the cluster by label. The ClusterResourceSet and its Secret are owned by the CAPI cluster,
+// and the manifest is re-applied by the ClusterResourceSet controller every time it changes.
+func (r *CAPIImportReconciler) reconcileImportResourceSet(ctx context.Context, capiCluster *clusterv1.Cluster, manifest string) error {
+	log := log.FromContext(ctx)
+
+	name := capiCluster.Name + importResourceSetSuffix
+	ownerRef := *metav1.NewControllerRef(capiCluster, clusterv1.GroupVersion.WithKind("Cluster"))
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: capiCluster.Namespace}}
+	if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		secret.Type = addonsv1.ClusterResourceSetSecretType
+		secret.Data = map[string][]byte{importResourceSetKey: []byte(manifest)}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reconciling import manifest secret %s: %w", name, err)
+	} else if op != controllerutil.OperationResultNone {
+		log.V(4).Info("Import manifest secret reconciled", "secret", name, "operation", op)
+	}
+
+	resourceSet := &addonsv1.ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: capiCluster.Namespace}}
+	if op, err := controllerutil.CreateOrUpdate(ctx, r.Client, resourceSet, func() error {
+		resourceSet.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		resourceSet.Spec.ClusterSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{importResourceSetLabel: capiCluster.Name},
+		}
+		resourceSet.Spec.Resources = []addonsv1.ResourceRef{{
+			Name: name,
+			Kind: string(addonsv1.SecretClusterResourceSetResourceKind),
+		}}
+		resourceSet.Spec.Strategy = string(addonsv1.ClusterResourceSetStrategyReconcile)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reconciling import ClusterResourceSet %s: %w", name, err)
+	} else if op != controllerutil.OperationResultNone {
+		log.Info("Import ClusterResourceSet reconciled", "clusterResourceSet", name, "operation", op)
+	}
+
+	labels := capiCluster.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[importResourceSetLabel] = capiCluster.Name
+	capiCluster.SetLabels(labels)
+
+	return nil
+}
+
+// cleanupImportResourceSet removes the import ClusterResourceSet and its Secret holding the registration token
+// when the CAPI cluster is switched from the Pull mode, and stops selecting the cluster.
+func (r *CAPIImportReconciler) cleanupImportResourceSet(ctx context.Context, capiCluster *clusterv1.Cluster) error {
+	if _, found := capiCluster.GetLabels()[importResourceSetLabel]; !found {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+
+	name := capiCluster.Name + importResourceSetSuffix
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: capiCluster.Namespace}
+
+	if err := r.Client.Delete(ctx, &addonsv1.ClusterResourceSet{ObjectMeta: objectMeta}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting import ClusterResourceSet %s: %w", name, err)
+	}
+
+	if err := r.Client.Delete(ctx, &corev1.Secret{ObjectMeta: objectMeta}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting import manifest secret %s: %w", name, err)
+	}
+
+	labels := capiCluster.GetLabels()
+	delete(labels, importResourceSetLabel)
+	capiCluster.SetLabels(labels)
+
+	log.Info("Import ClusterResourceSet removed in Push mode", "clusterResourceSet", name)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright © 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+	turtlesannotations "github.com/rancher/turtles/util/annotations"
+)
+
+var _ = Describe("Pull mode import", func() {
+	const manifest = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: cattle-system\n"
+
+	var (
+		r           *CAPIImportReconciler
+		fakeClient  client.Client
+		capiCluster *clusterv1.Cluster
+		policy      *turtlesv1.ClusterImportPolicy
+	)
+
+	BeforeEach(func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(manifest))
+		}))
+		DeferCleanup(server.Close)
+
+		capiCluster = &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Namespace:   "ns",
+			Annotations: map[string]string{turtlesannotations.ImportModeAnnotation: string(turtlesv1.ImportModePull)},
+		}}
+		policy = &turtlesv1.ClusterImportPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"}}
+
+		rancherClusterName := rancherClusterName(capiCluster)
+		rancherCluster := &managementv3.Cluster{ObjectMeta: metav1.ObjectMeta{
+			Name:      rancherClusterName,
+			Namespace: capiCluster.Namespace,
+			Labels: map[string]string{
+				capiClusterOwner:          capiCluster.Name,
+				capiClusterOwnerNamespace: capiCluster.Namespace,
+				ownedLabelName:            "",
+			},
+			Finalizers: []string{managementv3.CapiClusterFinalizer},
+		}}
+		token := &managementv3.ClusterRegistrationToken{
+			ObjectMeta: metav1.ObjectMeta{Name: rancherClusterName, Namespace: rancherClusterName},
+			Spec:       managementv3.ClusterRegistrationTokenSpec{ClusterName: rancherClusterName},
+			Status:     managementv3.ClusterRegistrationTokenStatus{ManifestURL: server.URL},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{importLabelName: "true"}}},
+				&managementv3.Setting{ObjectMeta: metav1.ObjectMeta{Name: "agent-tls-mode"}, Value: "system-store"},
+				capiCluster, policy, rancherCluster, token,
+			).
+			WithStatusSubresource(&managementv3.Cluster{}, &managementv3.ClusterRegistrationToken{}).
+			Build()
+		r = &CAPIImportReconciler{
+			Client:         fakeClient,
+			UncachedClient: fakeClient,
+			RancherClient:  fakeClient,
+			recorder:       record.NewFakeRecorder(10),
+			remoteClientGetter: func(context.Context, string, client.Client, client.ObjectKey) (client.Client, error) {
+				return nil, errors.New("workload cluster is not reachable")
+			},
+		}
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster)).To(Succeed())
+	})
+
+	It("should select the import mode from the annotation and the import policy", func() {
+		Expect(r.importMode(capiCluster, policy.Spec)).To(Equal(turtlesv1.ImportModePull))
+
+		delete(capiCluster.Annotations, turtlesannotations.ImportModeAnnotation)
+		Expect(r.importMode(capiCluster, policy.Spec)).To(Equal(turtlesv1.ImportModePush))
+
+		policy.Spec.Agent.Mode = turtlesv1.ImportModePull
+		Expect(r.importMode(capiCluster, policy.Spec)).To(Equal(turtlesv1.ImportModePull))
+
+		capiCluster.Annotations[turtlesannotations.ImportModeAnnotation] = "Agent"
+		_, err := r.importMode(capiCluster, policy.Spec)
+		Expect(err).To(MatchError(ContainSubstring("invalid import mode")))
+	})
+
+	It("should deliver the import manifest with a ClusterResourceSet", func() {
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		name := capiCluster.Name + importResourceSetSuffix
+
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: capiCluster.Namespace, Name: name}, secret)).To(Succeed())
+		Expect(secret.Type).To(Equal(addonsv1.ClusterResourceSetSecretType))
+		Expect(string(secret.Data[importResourceSetKey])).To(Equal(manifest))
+
+		resourceSet := &addonsv1.ClusterResourceSet{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: capiCluster.Namespace, Name: name}, resourceSet)).To(Succeed())
+		Expect(resourceSet.Spec.Strategy).To(Equal(string(addonsv1.ClusterResourceSetStrategyReconcile)))
+		Expect(resourceSet.Spec.Resources).To(ConsistOf(addonsv1.ResourceRef{Name: name, Kind: "Secret"}))
+		Expect(resourceSet.OwnerReferences).To(HaveLen(1))
+		Expect(resourceSet.OwnerReferences[0].Name).To(Equal(capiCluster.Name))
+
+		Expect(capiCluster.Labels).To(HaveKeyWithValue(importResourceSetLabel, capiCluster.Name))
+		Expect(resourceSet.Spec.ClusterSelector.MatchLabels).To(Equal(map[string]string{importResourceSetLabel: capiCluster.Name}))
+
+		condition := conditions.Get(capiCluster, turtlesv1.RancherImportedCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(turtlesv1.RancherImportManifestDeliveredReason))
+	})
+
+	It("should remove the ClusterResourceSet when switched to the Push mode", func() {
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capiCluster.Labels).To(HaveKey(importResourceSetLabel))
+
+		capiCluster.Annotations[turtlesannotations.ImportModeAnnotation] = string(turtlesv1.ImportModePush)
+
+		_, err = r.reconcile(ctx, capiCluster)
+		Expect(err).To(MatchError(ContainSubstring("workload cluster is not reachable")))
+
+		key := client.ObjectKey{Namespace: capiCluster.Namespace, Name: capiCluster.Name + importResourceSetSuffix}
+		Expect(fakeClient.Get(ctx, key, &addonsv1.ClusterResourceSet{})).To(MatchError(ContainSubstring("not found")))
+		Expect(fakeClient.Get(ctx, key, &corev1.Secret{})).To(MatchError(ContainSubstring("not found")))
+		Expect(capiCluster.Labels).ToNot(HaveKey(importResourceSetLabel))
+	})
+
+	It("should report the agent deployment from the Rancher cluster", func() {
+		rancherCluster := &managementv3.Cluster{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: capiCluster.Namespace, Name: rancherClusterName(capiCluster)}, rancherCluster)).
+			To(Succeed())
+		conditions.Set(rancherCluster, metav1.Condition{
+			Type:   managementv3.ClusterConditionAgentDeployed,
+			Status: metav1.ConditionTrue,
+			Reason: "Deployed",
+		})
+		Expect(fakeClient.Status().Update(ctx, rancherCluster)).To(Succeed())
+
+		_, err := r.reconcile(ctx, capiCluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := conditions.Get(capiCluster, turtlesv1.RancherImportedCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(turtlesv1.RancherImportAgentDeployedReason))
+	})
+})
@@ -43,7 +43,7 @@ const (
 // It may take a few minutes for the changes to take effect.
 // We need to wait since the cluster-api-operator library is going to use the mounted file
 // to deploy providers, therefore we need it to be synced with embedded and user overrides.
-func WaitForClusterctlConfigUpdate(ctx context.Context, client client.Client) (*controller.Result, error) {
+func WaitForClusterctlConfigUpdate(ctx context.Context, client, rancherClient client.Client) (*controller.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Load the mounted config from filesystem
@@ -56,7 +56,7 @@ func WaitForClusterctlConfigUpdate(ctx context.Context, client client.Client) (*
 	}
 
 	// Get the expected config with user overrides
-	config, err := clusterctl.ClusterConfig(ctx, client)
+	config, err := clusterctl.ClusterConfig(ctx, client, rancherClient)
 	if err != nil {
 		return &controller.Result{}, fmt.Errorf("getting updated ClusterctlConfig: %w", err)
 	}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api-operator/controller"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+const (
+	// The labels and the metadata key of the ConfigMaps the operator stores the fetched provider components in.
+	configMapNameLabel    = "provider.cluster.x-k8s.io/name"
+	configMapTypeLabel    = "provider.cluster.x-k8s.io/type"
+	configMapVersionLabel = "provider.cluster.x-k8s.io/version"
+	metadataConfigMapKey  = "metadata"
+
+	// contractCheckRequeueDuration is the interval the compatibility of an incompatible provider is checked again at.
+	contractCheckRequeueDuration = 5 * time.Minute
+
+	// v1beta1Contract is the previous CAPI contract, still compatible with the v1beta2 contract.
+	v1beta1Contract = "v1beta1"
+)
+
+// supportedContracts are the CAPI contracts Turtles supports providers for.
+var supportedContracts = []string{v1beta1Contract, clusterv1.GroupVersion.Version}
+
+// CheckContractCompatibility reads the CAPI contract of the provider version from the provider metadata.yaml, and
+// checks it against the contract of the installed core provider and the contracts supported by Turtles.
+// The install or upgrade of an incompatible provider version is blocked, and reported in the ContractCompatible condition.
+func CheckContractCompatibility(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*controller.Result, error) {
+	log := log.FromContext(ctx)
+
+	if provider.Spec.Version == "" {
+		return &controller.Result{}, nil
+	}
+
+	metadata, found, err := providerMetadata(ctx, cl, provider)
+	if err != nil {
+		return &controller.Result{}, fmt.Errorf("getting provider metadata: %w", err)
+	}
+
+	if !found {
+		log.V(4).Info("Provider metadata is not fetched yet, skipping contract check", "version", provider.Spec.Version)
+
+		return &controller.Result{}, nil
+	}
+
+	reason, message, err := contractCompatibility(ctx, cl, provider, metadata)
+	if err != nil {
+		return &controller.Result{}, err
+	}
+
+	if reason == turtlesv1.ContractCompatibleReason {
+		conditions.Set(provider, metav1.Condition{
+			Type:               turtlesv1.ContractCompatibleCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return &controller.Result{}, nil
+	}
+
+	log.Info("Provider version is not compatible, not installing it", "version", provider.Spec.Version, "reason", message)
+
+	conditions.Set(provider, metav1.Condition{
+		Type:               turtlesv1.ContractCompatibleCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return &controller.Result{RequeueAfter: contractCheckRequeueDuration}, nil
+}
+
+// contractCompatibility returns the ContractCompatible condition reason and message for the provider version.
+func contractCompatibility(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider,
+	metadata *clusterctlv1.Metadata,
+) (reason, message string, err error) {
+	providerVersion, err := version.ParseSemantic(provider.Spec.Version)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing provider version %s: %w", provider.Spec.Version, err)
+	}
+
+	releaseSeries := metadata.GetReleaseSeriesForVersion(providerVersion)
+	if releaseSeries == nil {
+		return turtlesv1.ContractReleaseSeriesMissingReason,
+			fmt.Sprintf("Provider metadata has no release series for version %s", provider.Spec.Version), nil
+	}
+
+	contract := releaseSeries.Contract
+
+	if !slices.Contains(supportedContracts, contract) {
+		return turtlesv1.ContractUnsupportedReason,
+			fmt.Sprintf("Provider version %s implements contract %s, Turtles supports %s",
+				provider.Spec.Version, contract, strings.Join(supportedContracts, ", ")), nil
+	}
+
+	if provider.Spec.Type == turtlesv1.Core {
+		return turtlesv1.ContractCompatibleReason, "Provider implements contract " + contract, nil
+	}
+
+	coreContract, err := coreProviderContract(ctx, cl)
+	if err != nil {
+		return "", "", err
+	}
+
+	if coreContract == "" {
+		return turtlesv1.ContractCompatibleReason,
+			fmt.Sprintf("Provider implements contract %s, core provider is not installed yet", contract), nil
+	}
+
+	if compatible := compatibleContracts(coreContract); !slices.Contains(compatible, contract) {
+		return turtlesv1.ContractIncompatibleReason,
+			fmt.Sprintf("Provider version %s implements contract %s, core provider contract %s is compatible with %s",
+				provider.Spec.Version, contract, coreContract, strings.Join(compatible, ", ")), nil
+	}
+
+	return turtlesv1.ContractCompatibleReason, "Provider implements contract " + contract, nil
+}
+
+// compatibleContracts returns the provider contracts compatible with the core provider contract. The v1beta2 contract
+// is compatible with v1beta1 providers until v1beta1 is removed.
+func compatibleContracts(coreContract string) []string {
+	if coreContract == clusterv1.GroupVersion.Version {
+		return []string{coreContract, v1beta1Contract}
+	}
+
+	return []string{coreContract}
+}
+
+// coreProviderContract returns the contract of the installed core provider, or an empty string when none is installed.
+func coreProviderContract(ctx context.Context, cl client.Client) (string, error) {
+	providers := &turtlesv1.CAPIProviderList{}
+	if err := cl.List(ctx, providers); err != nil {
+		return "", fmt.Errorf("listing providers: %w", err)
+	}
+
+	for _, provider := range providers.Items {
+		if provider.Spec.Type == turtlesv1.Core {
+			return ptr.Deref(provider.Status.Contract, ""), nil
+		}
+	}
+
+	return "", nil
+}
+
+// providerMetadata returns the metadata.yaml of the provider version, stored by the operator with the fetched components.
+func providerMetadata(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*clusterctlv1.Metadata, bool, error) {
+	configMaps := &corev1.ConfigMapList{}
+	if err := cl.List(ctx, configMaps, client.InNamespace(provider.GetNamespace()), client.MatchingLabels{
+		configMapNameLabel:    provider.GetName(),
+		configMapTypeLabel:    provider.GetType(),
+		configMapVersionLabel: provider.Spec.Version,
+	}); err != nil {
+		return nil, false, fmt.Errorf("listing provider components ConfigMaps: %w", err)
+	}
+
+	for _, configMap := range configMaps.Items {
+		data, ok := configMap.Data[metadataConfigMapKey]
+		if !ok {
+			continue
+		}
+
+		metadata := &clusterctlv1.Metadata{}
+		if err := yaml.Unmarshal([]byte(data), metadata); err != nil {
+			return nil, false, fmt.Errorf("parsing metadata of ConfigMap %s: %w", configMap.Name, err)
+		}
+
+		return metadata, true, nil
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Provider contract compatibility", func() {
+	const metadata = `apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3
+kind: Metadata
+releaseSeries:
+- major: 2
+  minor: 8
+  contract: v1beta1
+- major: 3
+  minor: 0
+  contract: v1beta2
+- major: 4
+  minor: 0
+  contract: v1beta3
+`
+
+	var (
+		provider     *turtlesv1.CAPIProvider
+		coreProvider *turtlesv1.CAPIProvider
+		fakeClient   client.Client
+	)
+
+	BeforeEach(func() {
+		provider = &turtlesv1.CAPIProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "capa-system"},
+			Spec:       turtlesv1.CAPIProviderSpec{Type: turtlesv1.Infrastructure},
+		}
+		coreProvider = &turtlesv1.CAPIProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-api", Namespace: "capi-system"},
+			Spec:       turtlesv1.CAPIProviderSpec{Type: turtlesv1.Core},
+		}
+		coreProvider.Status.Contract = ptr.To("v1beta2")
+	})
+
+	JustBeforeEach(func() {
+		configMaps := []client.Object{}
+
+		for _, version := range []string{"v2.8.0", "v3.0.0", "v3.1.0", "v4.0.0"} {
+			configMaps = append(configMaps, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "infrastructure-aws-" + version,
+					Namespace: provider.Namespace,
+					Labels: map[string]string{
+						configMapNameLabel:    provider.Name,
+						configMapTypeLabel:    provider.GetType(),
+						configMapVersionLabel: version,
+					},
+				},
+				Data: map[string]string{metadataConfigMapKey: metadata},
+			})
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithObjects(append(configMaps, coreProvider)...).
+			WithStatusSubresource(&turtlesv1.CAPIProvider{}).
+			Build()
+	})
+
+	DescribeTable("should report the contract compatibility of the provider version",
+		func(version, coreContract string, compatible bool, reason string) {
+			provider.Spec.Version = version
+			coreProvider.Status.Contract = ptr.To(coreContract)
+			Expect(fakeClient.Status().Update(ctx, coreProvider)).To(Succeed())
+
+			res, err := CheckContractCompatibility(ctx, fakeClient, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter > 0).To(Equal(!compatible))
+
+			condition := conditions.Get(provider, turtlesv1.ContractCompatibleCondition)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status == metav1.ConditionTrue).To(Equal(compatible))
+			Expect(condition.Reason).To(Equal(reason))
+		},
+		Entry("a v1beta2 provider with a v1beta2 core", "v3.0.0", "v1beta2", true, turtlesv1.ContractCompatibleReason),
+		Entry("a v1beta1 provider with a v1beta2 core", "v2.8.0", "v1beta2", true, turtlesv1.ContractCompatibleReason),
+		Entry("a v1beta2 provider with a v1beta1 core", "v3.0.0", "v1beta1", false, turtlesv1.ContractIncompatibleReason),
+		Entry("a provider without a release series", "v3.1.0", "v1beta2", false, turtlesv1.ContractReleaseSeriesMissingReason),
+		Entry("a provider contract unsupported by Turtles", "v4.0.0", "v1beta2", false, turtlesv1.ContractUnsupportedReason),
+	)
+
+	It("should skip the check until the provider metadata is fetched", func() {
+		provider.Spec.Version = "v5.0.0"
+
+		res, err := CheckContractCompatibility(ctx, fakeClient, provider)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(conditions.Has(provider, turtlesv1.ContractCompatibleCondition)).To(BeFalse())
+	})
+
+	Context("with a core provider", func() {
+		BeforeEach(func() {
+			provider.Spec.Type = turtlesv1.Core
+			coreProvider.Status.Contract = ptr.To("v1alpha4")
+		})
+
+		It("should only check the contracts supported by Turtles", func() {
+			provider.Spec.Version = "v2.8.0"
+
+			_, err := CheckContractCompatibility(ctx, fakeClient, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditions.IsTrue(provider, turtlesv1.ContractCompatibleCondition)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,131 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+
+	operatorv1 "sigs.k8s.io/cluster-api-operator/api/v1alpha2"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+// managerContainerName is the name of the manager container in the provider Deployment.
+const managerContainerName = "manager"
+
+// setControllerSpec translates the controller block of the provider into the manager and deployment fields of the
+// provider spec. Controller settings take precedence over the values already in these fields. A setting removed from
+// the controller block is removed from the fields too, unless it was changed there since it was last translated.
+// The translated controller block is recorded in the provider status.
+func setControllerSpec(provider *turtlesv1.CAPIProvider) {
+	desired := ptr.Deref(provider.Spec.Controller, turtlesv1.ControllerSpec{})
+	applied := ptr.Deref(provider.Status.Controller, turtlesv1.ControllerSpec{})
+
+	manager := ptr.Deref(provider.Spec.Manager, operatorv1.ManagerSpec{})
+	deployment := ptr.Deref(provider.Spec.Deployment, operatorv1.DeploymentSpec{})
+
+	deployment.Replicas = translatePtr(deployment.Replicas, desired.Replicas, applied.Replicas)
+	manager.MaxConcurrentReconciles = ptr.Deref(translatePtr(
+		ptr.To(manager.MaxConcurrentReconciles), desired.Concurrency, applied.Concurrency), 0)
+	manager.Verbosity = ptr.Deref(translatePtr(ptr.To(manager.Verbosity), desired.Verbosity, applied.Verbosity), 0)
+	manager.FeatureGates = translateMap(manager.FeatureGates, desired.FeatureGates, applied.FeatureGates)
+	manager.AdditionalArgs = translateMap(manager.AdditionalArgs, desired.ExtraArgs, applied.ExtraArgs)
+	deployment.Containers = translateResources(deployment.Containers, desired.Resources, applied.Resources)
+
+	provider.Spec.Manager = nil
+	if !equality.Semantic.DeepEqual(manager, operatorv1.ManagerSpec{}) {
+		provider.Spec.Manager = &manager
+	}
+
+	provider.Spec.Deployment = nil
+	if !equality.Semantic.DeepEqual(deployment, operatorv1.DeploymentSpec{}) {
+		provider.Spec.Deployment = &deployment
+	}
+
+	provider.Status.Controller = provider.Spec.Controller.DeepCopy()
+}
+
+// translatePtr returns the desired value when set. Otherwise the current value is kept, unless it is the last
+// applied value, which is removed.
+func translatePtr[T comparable](current, desired, applied *T) *T {
+	switch {
+	case desired != nil:
+		return ptr.To(*desired)
+	case applied != nil && current != nil && *current == *applied:
+		return nil
+	default:
+		return current
+	}
+}
+
+// translateMap returns the current map with the desired entries set, and the entries last applied but no longer
+// desired removed.
+func translateMap[V comparable](current, desired, applied map[string]V) map[string]V {
+	result := maps.Clone(current)
+	if result == nil {
+		result = map[string]V{}
+	}
+
+	for key, value := range applied {
+		if _, found := desired[key]; !found && result[key] == value {
+			delete(result, key)
+		}
+	}
+
+	maps.Copy(result, desired)
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// translateResources returns the containers with the desired resources set on the manager container.
+func translateResources(containers []operatorv1.ContainerSpec,
+	desired, applied *corev1.ResourceRequirements,
+) []operatorv1.ContainerSpec {
+	containers = slices.Clone(containers)
+
+	index := slices.IndexFunc(containers, func(container operatorv1.ContainerSpec) bool {
+		return container.Name == managerContainerName
+	})
+
+	switch {
+	case desired != nil && index < 0:
+		return append(containers, operatorv1.ContainerSpec{Name: managerContainerName, Resources: desired.DeepCopy()})
+	case desired != nil:
+		containers[index].Resources = desired.DeepCopy()
+	case applied != nil && index >= 0 && equality.Semantic.DeepEqual(containers[index].Resources, applied):
+		containers[index].Resources = nil
+
+		if equality.Semantic.DeepEqual(containers[index], operatorv1.ContainerSpec{Name: managerContainerName}) {
+			containers = slices.Delete(containers, index, index+1)
+		}
+	}
+
+	if len(containers) == 0 {
+		return nil
+	}
+
+	return containers
+}
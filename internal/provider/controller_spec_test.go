@@ -0,0 +1,124 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	operatorv1 "sigs.k8s.io/cluster-api-operator/api/v1alpha2"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Provider controller spec", func() {
+	var provider *turtlesv1.CAPIProvider
+
+	resources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+
+	BeforeEach(func() {
+		provider = &turtlesv1.CAPIProvider{Spec: turtlesv1.CAPIProviderSpec{
+			Controller: &turtlesv1.ControllerSpec{
+				Replicas:     ptr.To(2),
+				Resources:    resources,
+				Concurrency:  ptr.To(10),
+				Verbosity:    ptr.To(4),
+				FeatureGates: map[string]bool{"MachinePool": true},
+				ExtraArgs:    map[string]string{"--sync-period": "10m"},
+			},
+		}}
+	})
+
+	It("should translate the controller into the provider spec", func() {
+		setControllerSpec(provider)
+
+		Expect(provider.Spec.Deployment).To(Equal(&operatorv1.DeploymentSpec{
+			Replicas:   ptr.To(2),
+			Containers: []operatorv1.ContainerSpec{{Name: managerContainerName, Resources: resources}},
+		}))
+		Expect(provider.Spec.Manager).To(Equal(&operatorv1.ManagerSpec{
+			MaxConcurrentReconciles: 10,
+			Verbosity:               4,
+			FeatureGates:            map[string]bool{"MachinePool": true},
+			AdditionalArgs:          map[string]string{"--sync-period": "10m"},
+		}))
+		Expect(provider.Status.Controller).To(Equal(provider.Spec.Controller))
+	})
+
+	It("should merge the controller with the manager and deployment fields", func() {
+		provider.Spec.Manager = &operatorv1.ManagerSpec{
+			Verbosity:    1,
+			FeatureGates: map[string]bool{"MachinePool": false, "ClusterTopology": true},
+		}
+		provider.Spec.Deployment = &operatorv1.DeploymentSpec{
+			Replicas:   ptr.To(1),
+			Containers: []operatorv1.ContainerSpec{{Name: managerContainerName, Args: map[string]string{"--v": "2"}}},
+		}
+
+		setControllerSpec(provider)
+
+		Expect(*provider.Spec.Deployment.Replicas).To(Equal(2))
+		Expect(provider.Spec.Deployment.Containers).To(Equal([]operatorv1.ContainerSpec{{
+			Name:      managerContainerName,
+			Args:      map[string]string{"--v": "2"},
+			Resources: resources,
+		}}))
+		Expect(provider.Spec.Manager.Verbosity).To(Equal(4))
+		Expect(provider.Spec.Manager.FeatureGates).To(Equal(map[string]bool{"MachinePool": true, "ClusterTopology": true}))
+	})
+
+	It("should remove the settings removed from the controller", func() {
+		provider.Spec.Manager = &operatorv1.ManagerSpec{FeatureGates: map[string]bool{"ClusterTopology": true}}
+		provider.Spec.Deployment = &operatorv1.DeploymentSpec{
+			Containers: []operatorv1.ContainerSpec{{Name: managerContainerName, Args: map[string]string{"--v": "2"}}},
+		}
+
+		setControllerSpec(provider)
+
+		provider.Spec.Controller = &turtlesv1.ControllerSpec{Replicas: ptr.To(3)}
+		setControllerSpec(provider)
+
+		Expect(provider.Spec.Deployment).To(Equal(&operatorv1.DeploymentSpec{
+			Replicas:   ptr.To(3),
+			Containers: []operatorv1.ContainerSpec{{Name: managerContainerName, Args: map[string]string{"--v": "2"}}},
+		}))
+		Expect(provider.Spec.Manager).To(Equal(&operatorv1.ManagerSpec{FeatureGates: map[string]bool{"ClusterTopology": true}}))
+
+		provider.Spec.Controller = nil
+		provider.Spec.Deployment.Containers = nil
+		setControllerSpec(provider)
+
+		Expect(provider.Spec.Deployment).To(BeNil())
+		Expect(provider.Status.Controller).To(BeNil())
+	})
+
+	It("should keep the settings changed since they were translated", func() {
+		setControllerSpec(provider)
+
+		provider.Spec.Manager.Verbosity = 6
+		provider.Spec.Controller = nil
+		setControllerSpec(provider)
+
+		Expect(provider.Spec.Manager).To(Equal(&operatorv1.ManagerSpec{Verbosity: 6}))
+		Expect(provider.Spec.Deployment).To(BeNil())
+	})
+})
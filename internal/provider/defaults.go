@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"maps"
 	"strconv"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,11 +43,13 @@ const (
 	trueValue   = "true"
 )
 
-// SetProviderSpec sets the default values for the provider spec and updates to latest available version.
-func SetProviderSpec(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) error {
+// SetProviderSpec sets the default values for the provider spec, translates the controller tuning into it
+// and updates to latest available version.
+func SetProviderSpec(ctx context.Context, cl, rancherClient client.Client, provider *turtlesv1.CAPIProvider) error {
 	SetDefaultProviderSpec(provider)
+	setControllerSpec(provider)
 
-	if err := setLatestVersion(ctx, cl, provider); err != nil {
+	if err := setLatestVersion(ctx, cl, rancherClient, provider); err != nil {
 		return err
 	}
 
@@ -95,10 +98,10 @@ func SetDefaultProviderSpec(o operatorv1.GenericProvider) {
 	o.SetSpec(providerSpec)
 }
 
-func setLatestVersion(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) error {
+func setLatestVersion(ctx context.Context, cl, rancherClient client.Client, provider *turtlesv1.CAPIProvider) error {
 	log := log.FromContext(ctx)
 
-	config, err := clusterctl.ClusterConfig(ctx, cl)
+	config, err := clusterctl.ClusterConfig(ctx, cl, rancherClient)
 	if err != nil {
 		return err
 	}
@@ -110,6 +113,16 @@ func setLatestVersion(ctx context.Context, cl client.Client, provider *turtlesv1
 		return err
 	}
 
+	var (
+		pendingUntil time.Time
+		windowErr    error
+	)
+
+	// The maintenance window only delays updates, a provider without a version is installed right away.
+	if !latest && provider.Spec.EnableAutomaticUpdate && provider.Spec.Version != "" {
+		pendingUntil, windowErr = nextMaintenanceWindow(provider.Spec.MaintenanceWindow, time.Now())
+	}
+
 	switch {
 	case !knownProvider:
 		conditions.Set(provider, metav1.Condition{
@@ -138,6 +151,31 @@ func setLatestVersion(ctx context.Context, cl client.Client, provider *turtlesv1
 			Message:            "Provider version update available. Current latest is " + providerVersion,
 			LastTransitionTime: metav1.Now(),
 		})
+	case !latest && provider.Spec.EnableAutomaticUpdate && providerVersion == provider.Status.FailedVersion:
+		conditions.Set(provider, metav1.Condition{
+			Type:               string(turtlesv1.CheckLatestVersionTime),
+			Status:             metav1.ConditionFalse,
+			Reason:             turtlesv1.CheckLatestUpdateRolledBackReason,
+			Message:            "Provider version update to " + providerVersion + " was rolled back",
+			LastTransitionTime: metav1.Now(),
+		})
+	case !latest && provider.Spec.EnableAutomaticUpdate && windowErr != nil:
+		conditions.Set(provider, metav1.Condition{
+			Type:               string(turtlesv1.CheckLatestVersionTime),
+			Status:             metav1.ConditionFalse,
+			Reason:             turtlesv1.CheckLatestInvalidMaintenanceWindowReason,
+			Message:            windowErr.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+	case !latest && provider.Spec.EnableAutomaticUpdate && !pendingUntil.IsZero():
+		conditions.Set(provider, metav1.Condition{
+			Type:   string(turtlesv1.CheckLatestVersionTime),
+			Status: metav1.ConditionFalse,
+			Reason: turtlesv1.CheckLatestUpdatePendingReason,
+			Message: fmt.Sprintf("UpdatePending until %s, provider version %s available",
+				pendingUntil.Format(time.RFC3339), providerVersion),
+			LastTransitionTime: metav1.Now(),
+		})
 	case !latest && provider.Spec.EnableAutomaticUpdate:
 		lastCheck := conditions.Get(provider, string(turtlesv1.CheckLatestVersionTime))
 		updatedMessage := "Updated to latest " + providerVersion + " version"
@@ -154,6 +192,8 @@ func setLatestVersion(ctx context.Context, cl client.Client, provider *turtlesv1
 			})
 		}
 
+		startUpgrade(provider, providerVersion)
+
 		provider.Spec.Version = providerVersion
 	}
 
@@ -0,0 +1,246 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/cluster-api-operator/controller"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+// dependencyRequeueDuration is the interval the dependencies of a waiting provider are checked again at.
+const dependencyRequeueDuration = 30 * time.Second
+
+// reference returns the reference to the provider used in the dependencies of other providers.
+func reference(provider *turtlesv1.CAPIProvider) turtlesv1.ProviderReference {
+	return turtlesv1.ProviderReference{Name: provider.ProviderName(), Type: provider.Spec.Type}
+}
+
+func referenceString(ref turtlesv1.ProviderReference) string {
+	return fmt.Sprintf("%s/%s", ref.Type, ref.Name)
+}
+
+// DependsOn returns true when the provider depends on the dependency provider.
+func DependsOn(provider, dependency *turtlesv1.CAPIProvider) bool {
+	return slices.Contains(provider.Spec.DependsOn, reference(dependency))
+}
+
+// CheckDependencies waits for the providers the provider depends on to be ready, and to have rolled out their version,
+// before the provider is installed or upgraded. Missing dependencies and dependency cycles block the provider.
+// The result is reported in the DependenciesReady condition.
+func CheckDependencies(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*controller.Result, error) {
+	if len(provider.Spec.DependsOn) == 0 {
+		conditions.Delete(provider, turtlesv1.DependenciesReadyCondition)
+
+		return &controller.Result{}, nil
+	}
+
+	providers := &turtlesv1.CAPIProviderList{}
+	if err := cl.List(ctx, providers); err != nil {
+		return &controller.Result{}, fmt.Errorf("listing providers: %w", err)
+	}
+
+	reason, message := dependenciesReady(provider, providers.Items)
+	if reason == turtlesv1.DependenciesReadyReason {
+		conditions.Set(provider, metav1.Condition{
+			Type:               turtlesv1.DependenciesReadyCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return &controller.Result{}, nil
+	}
+
+	log.FromContext(ctx).Info("Waiting for provider dependencies", "reason", message)
+
+	conditions.Set(provider, metav1.Condition{
+		Type:               turtlesv1.DependenciesReadyCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return &controller.Result{RequeueAfter: dependencyRequeueDuration}, nil
+}
+
+// dependenciesReady returns the DependenciesReady condition reason and message of the provider.
+func dependenciesReady(provider *turtlesv1.CAPIProvider, providers []turtlesv1.CAPIProvider) (reason, message string) {
+	byReference := map[turtlesv1.ProviderReference]*turtlesv1.CAPIProvider{}
+	for i := range providers {
+		byReference[reference(&providers[i])] = &providers[i]
+	}
+
+	// The provider from the reconciler has the latest changes of this reconcile.
+	byReference[reference(provider)] = provider
+
+	if cycle := dependencyCycle(reference(provider), byReference); len(cycle) > 0 {
+		return turtlesv1.DependencyCycleReason, "Provider dependencies form a cycle: " + strings.Join(cycle, " -> ")
+	}
+
+	for _, ref := range provider.Spec.DependsOn {
+		dependency, found := byReference[ref]
+
+		switch {
+		case !found:
+			return turtlesv1.DependencyNotFoundReason, fmt.Sprintf("Provider %s does not exist", referenceString(ref))
+		case !dependency.DeletionTimestamp.IsZero():
+			return turtlesv1.DependencyNotReadyReason, fmt.Sprintf("Provider %s is being deleted", referenceString(ref))
+		case !conditions.IsTrue(dependency, clusterv1.ReadyCondition):
+			return turtlesv1.DependencyNotReadyReason, fmt.Sprintf("Provider %s is not ready", referenceString(ref))
+		case ptr.Deref(dependency.Status.InstalledVersion, "") != dependency.Spec.Version:
+			return turtlesv1.DependencyNotReadyReason, fmt.Sprintf("Provider %s is being upgraded to %s",
+				referenceString(ref), dependency.Spec.Version)
+		}
+	}
+
+	return turtlesv1.DependenciesReadyReason, "All provider dependencies are ready"
+}
+
+// dependencyCycle returns the path of a dependency cycle going through the provider, or nil when there is none.
+func dependencyCycle(start turtlesv1.ProviderReference,
+	byReference map[turtlesv1.ProviderReference]*turtlesv1.CAPIProvider,
+) []string {
+	return dependencyPath(start, start, byReference)
+}
+
+// dependencyPath returns a path of dependencies from the provider to the target provider, or nil when there is none.
+func dependencyPath(start, target turtlesv1.ProviderReference,
+	byReference map[turtlesv1.ProviderReference]*turtlesv1.CAPIProvider,
+) []string {
+	visited := map[turtlesv1.ProviderReference]bool{}
+
+	var visit func(ref turtlesv1.ProviderReference, path []string) []string
+
+	visit = func(ref turtlesv1.ProviderReference, path []string) []string {
+		provider, found := byReference[ref]
+		if !found {
+			return nil
+		}
+
+		for _, dependency := range provider.Spec.DependsOn {
+			next := append(slices.Clone(path), referenceString(dependency))
+
+			if dependency == target {
+				return next
+			}
+
+			if visited[dependency] {
+				continue
+			}
+
+			visited[dependency] = true
+
+			if found := visit(dependency, next); found != nil {
+				return found
+			}
+		}
+
+		return nil
+	}
+
+	return visit(start, []string{referenceString(start)})
+}
+
+// WaitForDependents blocks the deletion of the provider until no other provider depends on it,
+// so providers are deleted in the reverse order of their dependencies. Dependents forming a cycle with
+// the provider are not waited for, as they would wait for the provider as well.
+// The result is reported in the DependentsDeleted condition.
+func WaitForDependents(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*controller.Result, error) {
+	log := log.FromContext(ctx)
+
+	providers := &turtlesv1.CAPIProviderList{}
+	if err := cl.List(ctx, providers); err != nil {
+		return &controller.Result{}, fmt.Errorf("listing providers: %w", err)
+	}
+
+	byReference := map[turtlesv1.ProviderReference]*turtlesv1.CAPIProvider{}
+	for i := range providers.Items {
+		byReference[reference(&providers.Items[i])] = &providers.Items[i]
+	}
+
+	byReference[reference(provider)] = provider
+
+	dependents, cycles := []string{}, []string{}
+
+	for i := range providers.Items {
+		dependent := &providers.Items[i]
+		if !DependsOn(dependent, provider) {
+			continue
+		}
+
+		if cycle := dependencyPath(reference(provider), reference(dependent), byReference); cycle != nil {
+			cycles = append(cycles, strings.Join(append(cycle, referenceString(reference(provider))), " -> "))
+			continue
+		}
+
+		dependents = append(dependents, referenceString(reference(dependent)))
+	}
+
+	slices.Sort(dependents)
+	slices.Sort(cycles)
+
+	switch {
+	case len(dependents) > 0:
+		log.Info("Waiting for dependent providers to be deleted", "dependents", dependents)
+
+		conditions.Set(provider, metav1.Condition{
+			Type:               turtlesv1.DependentsDeletedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             turtlesv1.DependentsNotDeletedReason,
+			Message:            "Waiting for dependent providers to be deleted: " + strings.Join(dependents, ", "),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return &controller.Result{RequeueAfter: dependencyRequeueDuration}, nil
+	case len(cycles) > 0:
+		log.Info("Dependent providers form a cycle, not waiting for them to be deleted", "cycles", cycles)
+
+		conditions.Set(provider, metav1.Condition{
+			Type:               turtlesv1.DependentsDeletedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             turtlesv1.DependentCycleIgnoredReason,
+			Message:            "Dependent providers form a cycle and are not waited for: " + strings.Join(cycles, ", "),
+			LastTransitionTime: metav1.Now(),
+		})
+	case conditions.Has(provider, turtlesv1.DependentsDeletedCondition):
+		conditions.Set(provider, metav1.Condition{
+			Type:               turtlesv1.DependentsDeletedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             turtlesv1.DependentsDeletedReason,
+			Message:            "All dependent providers are deleted",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	return &controller.Result{}, nil
+}
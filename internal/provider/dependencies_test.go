@@ -0,0 +1,165 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Provider dependencies", func() {
+	var (
+		bootstrap    *turtlesv1.CAPIProvider
+		controlPlane *turtlesv1.CAPIProvider
+		objects      []client.Object
+		fakeClient   client.Client
+	)
+
+	bootstrapRef := turtlesv1.ProviderReference{Name: "rke2", Type: turtlesv1.Bootstrap}
+	controlPlaneRef := turtlesv1.ProviderReference{Name: "rke2", Type: turtlesv1.ControlPlane}
+
+	BeforeEach(func() {
+		bootstrap = &turtlesv1.CAPIProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "rke2-bootstrap", Namespace: "rke2-bootstrap-system"},
+			Spec:       turtlesv1.CAPIProviderSpec{Name: "rke2", Type: turtlesv1.Bootstrap},
+		}
+		bootstrap.Spec.Version = "v0.20.0"
+		bootstrap.Status.InstalledVersion = ptr.To("v0.20.0")
+		conditions.Set(bootstrap, metav1.Condition{Type: clusterv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: "Ready"})
+
+		controlPlane = &turtlesv1.CAPIProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "rke2-control-plane", Namespace: "rke2-control-plane-system"},
+			Spec: turtlesv1.CAPIProviderSpec{
+				Name:      "rke2",
+				Type:      turtlesv1.ControlPlane,
+				DependsOn: []turtlesv1.ProviderReference{bootstrapRef},
+			},
+		}
+
+		objects = []client.Object{bootstrap, controlPlane}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().WithObjects(objects...).Build()
+	})
+
+	It("should proceed once the dependencies are ready", func() {
+		res, err := CheckDependencies(ctx, fakeClient, controlPlane)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(conditions.IsTrue(controlPlane, turtlesv1.DependenciesReadyCondition)).To(BeTrue())
+	})
+
+	It("should not set the condition on providers without dependencies", func() {
+		res, err := CheckDependencies(ctx, fakeClient, bootstrap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(conditions.Has(bootstrap, turtlesv1.DependenciesReadyCondition)).To(BeFalse())
+	})
+
+	Context("when a dependency is being upgraded", func() {
+		BeforeEach(func() {
+			bootstrap.Spec.Version = "v0.21.0"
+		})
+
+		It("should wait for the dependency", func() {
+			res, err := CheckDependencies(ctx, fakeClient, controlPlane)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(dependencyRequeueDuration))
+			Expect(conditions.GetReason(controlPlane, turtlesv1.DependenciesReadyCondition)).
+				To(Equal(turtlesv1.DependencyNotReadyReason))
+		})
+	})
+
+	Context("when a dependency doesn't exist", func() {
+		BeforeEach(func() {
+			objects = []client.Object{controlPlane}
+		})
+
+		It("should wait for the dependency", func() {
+			res, err := CheckDependencies(ctx, fakeClient, controlPlane)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(dependencyRequeueDuration))
+			Expect(conditions.GetReason(controlPlane, turtlesv1.DependenciesReadyCondition)).
+				To(Equal(turtlesv1.DependencyNotFoundReason))
+		})
+	})
+
+	Context("when the dependencies form a cycle", func() {
+		BeforeEach(func() {
+			bootstrap.Spec.DependsOn = []turtlesv1.ProviderReference{controlPlaneRef}
+		})
+
+		It("should report the cycle", func() {
+			res, err := CheckDependencies(ctx, fakeClient, controlPlane)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(dependencyRequeueDuration))
+
+			condition := conditions.Get(controlPlane, turtlesv1.DependenciesReadyCondition)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Reason).To(Equal(turtlesv1.DependencyCycleReason))
+			Expect(condition.Message).To(ContainSubstring("controlPlane/rke2 -> bootstrap/rke2 -> controlPlane/rke2"))
+		})
+	})
+
+	It("should delete a provider after the providers depending on it", func() {
+		res, err := WaitForDependents(ctx, fakeClient, bootstrap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(dependencyRequeueDuration))
+		Expect(conditions.GetReason(bootstrap, turtlesv1.DependentsDeletedCondition)).
+			To(Equal(turtlesv1.DependentsNotDeletedReason))
+
+		Expect(fakeClient.Delete(ctx, controlPlane)).To(Succeed())
+
+		res, err = WaitForDependents(ctx, fakeClient, bootstrap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(conditions.GetReason(bootstrap, turtlesv1.DependentsDeletedCondition)).
+			To(Equal(turtlesv1.DependentsDeletedReason))
+	})
+
+	Context("when the deleted providers form a cycle", func() {
+		BeforeEach(func() {
+			bootstrap.Spec.DependsOn = []turtlesv1.ProviderReference{controlPlaneRef}
+		})
+
+		It("should delete both providers without waiting for each other", func() {
+			for _, provider := range []*turtlesv1.CAPIProvider{bootstrap, controlPlane} {
+				res, err := WaitForDependents(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.RequeueAfter).To(BeZero())
+
+				condition := conditions.Get(provider, turtlesv1.DependentsDeletedCondition)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(turtlesv1.DependentCycleIgnoredReason))
+			}
+
+			Expect(conditions.GetMessage(bootstrap, turtlesv1.DependentsDeletedCondition)).
+				To(ContainSubstring("bootstrap/rke2 -> controlPlane/rke2 -> bootstrap/rke2"))
+		})
+	})
+})
@@ -0,0 +1,248 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embed the time zone database, so maintenance window time zones resolve in images without one.
+	_ "time/tzdata"
+
+	"sigs.k8s.io/cluster-api-operator/controller"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+// cronSearchLimit bounds the search of the next maintenance window start.
+const cronSearchLimit = 5
+
+// cronSchedule is a parsed cron expression, with each field stored as a bit set of its allowed values.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek are set for a `*` day field, the other day field alone restricts the days then.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// cronField describes the allowed range of a cron expression field.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// parseCron parses a cron expression with the minute, hour, day of month, month and day of week fields.
+// Fields support `*`, values, ranges, lists and steps. A day of week of 7 is Sunday.
+func parseCron(expression string) (*cronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression %q, found %d", len(cronFields), expression, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, fmt.Errorf("invalid %s in cron expression %q: %w", cronFields[i].name, expression, err)
+		}
+	}
+
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &cronSchedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dayOfMonth:    bits[2],
+		month:         bits[3],
+		dayOfWeek:     bits[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+// parseCronField returns the bit set of the values allowed by a cron expression field.
+func parseCronField(field string, r cronField) (uint64, error) {
+	var bits uint64
+
+	for item := range strings.SplitSeq(field, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepExpr)
+			}
+		}
+
+		start, end := r.min, r.max
+
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+
+			var err error
+			if start, err = parseCronValue(startExpr, r); err != nil {
+				return 0, err
+			}
+
+			end = start
+
+			switch {
+			case isRange:
+				if end, err = parseCronValue(endExpr, r); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				end = r.max
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("invalid range %q", rangeExpr)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(expr string, r cronField) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < r.min || value > r.max {
+		return 0, fmt.Errorf("value %q is not between %d and %d", expr, r.min, r.max)
+	}
+
+	return value, nil
+}
+
+// matches returns true when the schedule fires at the minute of the given time.
+func (s *cronSchedule) matches(t time.Time) bool {
+	return s.minute&(1<<t.Minute()) != 0 &&
+		s.hour&(1<<t.Hour()) != 0 &&
+		s.month&(1<<int(t.Month())) != 0 &&
+		s.dayMatches(t)
+}
+
+// dayMatches returns true when the day of the given time is allowed. As in cron, a day matching either the day
+// of month or the day of week is allowed when both are restricted.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+// next returns the first time after the given one the schedule fires at, or a zero time when it doesn't fire
+// within the search limit.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+	limit := t.AddDate(cronSearchLimit, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// nextMaintenanceWindow returns the start of the next maintenance window, or a zero time when there is
+// no maintenance window or it is open at the given time.
+func nextMaintenanceWindow(window *turtlesv1.MaintenanceWindow, now time.Time) (time.Time, error) {
+	if window == nil {
+		return time.Time{}, nil
+	}
+
+	schedule, err := parseCron(window.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if window.Duration.Duration <= 0 {
+		return time.Time{}, errors.New("maintenance window duration must be positive")
+	}
+
+	location, err := time.LoadLocation(cmp.Or(window.TimeZone, "UTC"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid maintenance window time zone %q: %w", window.TimeZone, err)
+	}
+
+	now = now.In(location)
+
+	// The window is open when the schedule fired within the window duration.
+	start := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, location)
+	for ; now.Sub(start) < window.Duration.Duration; start = start.Add(-time.Minute) {
+		if schedule.matches(start) {
+			return time.Time{}, nil
+		}
+	}
+
+	next := schedule.next(now)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", window.Schedule)
+	}
+
+	return next, nil
+}
+
+// RequeueForMaintenanceWindow requeues a provider with an update pending for its maintenance window until the window opens.
+func RequeueForMaintenanceWindow(provider *turtlesv1.CAPIProvider) *controller.Result {
+	if conditions.GetReason(provider, string(turtlesv1.CheckLatestVersionTime)) != turtlesv1.CheckLatestUpdatePendingReason {
+		return &controller.Result{}
+	}
+
+	next, err := nextMaintenanceWindow(provider.Spec.MaintenanceWindow, time.Now())
+	if err != nil || next.IsZero() {
+		return &controller.Result{}
+	}
+
+	return &controller.Result{RequeueAfter: time.Until(next)}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Maintenance window", func() {
+	// Saturday, 1 March 2025.
+	saturday := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	DescribeTable("should find the next start of a cron expression",
+		func(expression string, from, expected time.Time) {
+			schedule, err := parseCron(expression)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schedule.next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", saturday, saturday.Add(time.Minute)),
+		Entry("a daily time", "30 2 * * *", saturday.Add(3*time.Hour), saturday.AddDate(0, 0, 1).Add(150*time.Minute)),
+		Entry("a step", "*/15 * * * *", saturday.Add(16*time.Minute), saturday.Add(30*time.Minute)),
+		Entry("a list of days of week", "0 4 * * 1,3", saturday, saturday.AddDate(0, 0, 2).Add(4*time.Hour)),
+		Entry("Sunday as 7", "0 0 * * 7", saturday, saturday.AddDate(0, 0, 1)),
+		Entry("a day of month range", "0 0 10-12 * *", saturday, saturday.AddDate(0, 0, 9)),
+		Entry("either restricted day field", "0 0 15 * 1", saturday, saturday.AddDate(0, 0, 2)),
+		Entry("a month", "0 0 1 6 *", saturday, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("should reject invalid cron expressions",
+		func(expression string) {
+			_, err := parseCron(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing fields", "0 2 * *"),
+		Entry("an out of range value", "60 * * * *"),
+		Entry("a reversed range", "0 5-2 * * *"),
+		Entry("an invalid step", "*/0 * * * *"),
+		Entry("a name", "0 0 * * MON"),
+	)
+
+	It("should report an open window", func() {
+		window := &turtlesv1.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+
+		next, err := nextMaintenanceWindow(window, saturday.Add(3*time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next.IsZero()).To(BeTrue())
+	})
+
+	It("should report the next window start when closed", func() {
+		window := &turtlesv1.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+
+		next, err := nextMaintenanceWindow(window, saturday.Add(6*time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(BeTemporally("==", saturday.AddDate(0, 0, 7).Add(2*time.Hour)))
+	})
+
+	It("should evaluate the schedule in the window time zone", func() {
+		window := &turtlesv1.MaintenanceWindow{
+			Schedule: "0 2 * * *",
+			Duration: metav1.Duration{Duration: time.Hour},
+			TimeZone: "Asia/Tokyo",
+		}
+
+		// 17:30 UTC is 02:30 in Tokyo.
+		next, err := nextMaintenanceWindow(window, saturday.Add(17*time.Hour+30*time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next.IsZero()).To(BeTrue())
+
+		next, err = nextMaintenanceWindow(window, saturday.Add(12*time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(BeTemporally("==", saturday.Add(17*time.Hour)))
+	})
+
+	It("should reject an invalid time zone or duration", func() {
+		_, err := nextMaintenanceWindow(&turtlesv1.MaintenanceWindow{
+			Schedule: "0 2 * * *",
+			Duration: metav1.Duration{Duration: time.Hour},
+			TimeZone: "Mars/Olympus",
+		}, saturday)
+		Expect(err).To(HaveOccurred())
+
+		_, err = nextMaintenanceWindow(&turtlesv1.MaintenanceWindow{Schedule: "0 2 * * *"}, saturday)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with automatic updates enabled", func() {
+		var (
+			provider   *turtlesv1.CAPIProvider
+			fakeClient client.Client
+		)
+
+		BeforeEach(func() {
+			fakeClient = fake.NewClientBuilder().WithObjects(&managementv3.Setting{
+				ObjectMeta: metav1.ObjectMeta{Name: "system-default-registry"},
+			}).Build()
+			provider = &turtlesv1.CAPIProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-api", Namespace: "capi-system"},
+				Spec: turtlesv1.CAPIProviderSpec{
+					Type:                  turtlesv1.Core,
+					EnableAutomaticUpdate: true,
+					// A window opening in a year from now is closed.
+					MaintenanceWindow: &turtlesv1.MaintenanceWindow{
+						Schedule: fmt.Sprintf("0 0 1 %d *", time.Now().UTC().AddDate(0, -1, 0).Month()),
+						Duration: metav1.Duration{Duration: time.Minute},
+					},
+				},
+			}
+		})
+
+		It("should install a provider without a version outside the window", func() {
+			Expect(setLatestVersion(ctx, fakeClient, fakeClient, provider)).To(Succeed())
+			Expect(provider.Spec.Version).ToNot(BeEmpty())
+			Expect(conditions.GetReason(provider, turtlesv1.CheckLatestVersionTime)).
+				ToNot(Equal(turtlesv1.CheckLatestUpdatePendingReason))
+		})
+
+		It("should delay the update of a provider outside the window", func() {
+			provider.Spec.Version = "v0.0.1"
+
+			Expect(setLatestVersion(ctx, fakeClient, fakeClient, provider)).To(Succeed())
+			Expect(provider.Spec.Version).To(Equal("v0.0.1"))
+			Expect(conditions.GetReason(provider, turtlesv1.CheckLatestVersionTime)).
+				To(Equal(turtlesv1.CheckLatestUpdatePendingReason))
+		})
+	})
+
+	It("should requeue a provider with a pending update until the window opens", func() {
+		provider := &turtlesv1.CAPIProvider{Spec: turtlesv1.CAPIProviderSpec{
+			MaintenanceWindow: &turtlesv1.MaintenanceWindow{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Minute}},
+		}}
+		Expect(RequeueForMaintenanceWindow(provider).RequeueAfter).To(BeZero())
+
+		conditions.Set(provider, metav1.Condition{
+			Type:   turtlesv1.CheckLatestVersionTime,
+			Status: metav1.ConditionFalse,
+			Reason: turtlesv1.CheckLatestUpdatePendingReason,
+		})
+
+		requeueAfter := RequeueForMaintenanceWindow(provider).RequeueAfter
+		Expect(requeueAfter).To(BeNumerically(">", 0))
+		Expect(requeueAfter).To(BeNumerically("<=", 24*time.Hour))
+	})
+})
@@ -0,0 +1,221 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/cluster-api-operator/controller"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+const (
+	// defaultUpgradeTimeout is the upgrade strategy timeout used when none is set.
+	defaultUpgradeTimeout = 10 * time.Minute
+	// upgradeHealthCheckInterval is the interval between health checks of an upgraded provider.
+	upgradeHealthCheckInterval = 30 * time.Second
+)
+
+// startUpgrade records the upgrade of the provider to the version under the upgrade strategy, so the provider can be
+// rolled back when the version doesn't become healthy. Fresh installations are not recorded, and an upgrade superseding
+// one in progress keeps its previous version.
+func startUpgrade(provider *turtlesv1.CAPIProvider, version string) {
+	if provider.Spec.UpgradeStrategy == nil || provider.Spec.Version == "" || provider.Spec.Version == version {
+		return
+	}
+
+	previousVersion := provider.Spec.Version
+	if provider.Status.Upgrade != nil {
+		previousVersion = provider.Status.Upgrade.PreviousVersion
+	}
+
+	provider.Status.Upgrade = &turtlesv1.UpgradeStatus{
+		PreviousVersion: previousVersion,
+		Version:         version,
+		StartTime:       metav1.Now(),
+	}
+}
+
+// CheckUpgradeHealth waits for a provider upgraded under the upgrade strategy to install the new version, for its
+// Deployments to become Available and for its CRDs to be established. When the provider doesn't become healthy
+// within the upgrade strategy timeout, it is rolled back to the previous version and the version is recorded as failed.
+func CheckUpgradeHealth(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*controller.Result, error) {
+	log := log.FromContext(ctx)
+
+	upgrade := provider.Status.Upgrade
+	if upgrade == nil {
+		return &controller.Result{}, nil
+	}
+
+	if provider.Spec.UpgradeStrategy == nil {
+		log.Info("Upgrade strategy removed, not checking the provider upgrade health", "version", upgrade.Version)
+
+		provider.Status.Upgrade = nil
+
+		return &controller.Result{}, nil
+	}
+
+	healthy, err := upgradeHealthy(ctx, cl, provider)
+	if err != nil {
+		return &controller.Result{}, fmt.Errorf("checking provider upgrade health: %w", err)
+	}
+
+	if healthy {
+		log.Info("Provider upgrade is healthy", "version", upgrade.Version)
+
+		provider.Status.Upgrade = nil
+
+		if conditions.Has(provider, turtlesv1.ProviderUpgradeRolledBackCondition) {
+			conditions.Set(provider, metav1.Condition{
+				Type:               turtlesv1.ProviderUpgradeRolledBackCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             turtlesv1.ProviderUpgradeSucceededReason,
+				Message:            "Provider is upgraded to " + upgrade.Version,
+				LastTransitionTime: metav1.Now(),
+			})
+		}
+
+		return &controller.Result{}, nil
+	}
+
+	timeout := upgradeTimeout(provider)
+	if remaining := timeout - time.Since(upgrade.StartTime.Time); remaining > 0 {
+		return &controller.Result{RequeueAfter: min(remaining, upgradeHealthCheckInterval)}, nil
+	}
+
+	log.Info("Provider upgrade did not become healthy in time, rolling back",
+		"version", upgrade.Version, "previousVersion", upgrade.PreviousVersion, "timeout", timeout)
+
+	provider.Spec.Version = upgrade.PreviousVersion
+	provider.Status.FailedVersion = upgrade.Version
+	provider.Status.Upgrade = nil
+
+	conditions.Set(provider, metav1.Condition{
+		Type:   turtlesv1.ProviderUpgradeRolledBackCondition,
+		Status: metav1.ConditionTrue,
+		Reason: turtlesv1.ProviderUpgradeTimedOutReason,
+		Message: fmt.Sprintf("Version %s did not become healthy within %s, rolled back to %s",
+			upgrade.Version, timeout, upgrade.PreviousVersion),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return &controller.Result{Requeue: true}, nil
+}
+
+// RollbackTimedOutUpgrade rolls back a provider upgraded under the upgrade strategy when it didn't become healthy within
+// the upgrade strategy timeout. It runs before the install phases, which stop the reconciliation when the new version
+// fails to be fetched or installed, so such a version is rolled back as well.
+func RollbackTimedOutUpgrade(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (*controller.Result, error) {
+	upgrade := provider.Status.Upgrade
+	if upgrade == nil || provider.Spec.UpgradeStrategy == nil || time.Since(upgrade.StartTime.Time) < upgradeTimeout(provider) {
+		return &controller.Result{}, nil
+	}
+
+	return CheckUpgradeHealth(ctx, cl, provider)
+}
+
+// upgradeTimeout returns the upgrade strategy timeout of the provider.
+func upgradeTimeout(provider *turtlesv1.CAPIProvider) time.Duration {
+	return cmp.Or(provider.Spec.UpgradeStrategy.Timeout.Duration, defaultUpgradeTimeout)
+}
+
+// upgradeHealthy returns true when the upgraded version of the provider is installed, all its Deployments
+// are Available and rolled out, and all its CRDs are established.
+func upgradeHealthy(ctx context.Context, cl client.Client, provider *turtlesv1.CAPIProvider) (bool, error) {
+	if ptr.Deref(provider.Status.InstalledVersion, "") != provider.Status.Upgrade.Version {
+		return false, nil
+	}
+
+	listOpts, err := getSelector(provider)
+	if err != nil {
+		return false, fmt.Errorf("getting selector: %w", err)
+	}
+
+	deploymentList := &appsv1.DeploymentList{}
+	if err := cl.List(ctx, deploymentList, listOpts...); err != nil {
+		return false, fmt.Errorf("listing Deployments: %w", err)
+	}
+
+	if len(deploymentList.Items) == 0 {
+		return false, nil
+	}
+
+	for _, deployment := range deploymentList.Items {
+		if !deploymentAvailable(&deployment) {
+			return false, nil
+		}
+	}
+
+	selector, err := getLabelSelector(provider)
+	if err != nil {
+		return false, fmt.Errorf("getting selector: %w", err)
+	}
+
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := cl.List(ctx, crdList, selector); err != nil {
+		return false, fmt.Errorf("listing CustomResourceDefinitions: %w", err)
+	}
+
+	for _, crd := range crdList.Items {
+		if !crdEstablished(&crd) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// deploymentAvailable returns true when the Deployment observed its latest generation, updated all its replicas
+// and is Available.
+func deploymentAvailable(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < ptr.Deref(deployment.Spec.Replicas, 1) {
+		return false
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// crdEstablished returns true when the CustomResourceDefinition is established.
+func crdEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+/*
+Copyright © 2023 - 2025 SUSE LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
+)
+
+var _ = Describe("Provider upgrade strategy", func() {
+	var (
+		provider   *turtlesv1.CAPIProvider
+		deployment *appsv1.Deployment
+		crd        *apiextensionsv1.CustomResourceDefinition
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		provider = &turtlesv1.CAPIProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "capa-system"},
+			Spec: turtlesv1.CAPIProviderSpec{
+				Type:            turtlesv1.Infrastructure,
+				UpgradeStrategy: &turtlesv1.UpgradeStrategy{Timeout: metav1.Duration{Duration: time.Minute}},
+			},
+		}
+		provider.Spec.Version = "v2.8.0"
+
+		providerLabels := map[string]string{CAPIProviderLabel: "infrastructure-aws"}
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "capa-controller-manager", Namespace: "capa-system", Labels: providerLabels},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+			Status: appsv1.DeploymentStatus{
+				UpdatedReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		}
+		crd = &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "awsclusters.infrastructure.cluster.x-k8s.io", Labels: providerLabels},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{
+					Type:   apiextensionsv1.Established,
+					Status: apiextensionsv1.ConditionTrue,
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().WithObjects(deployment, crd).Build()
+	})
+
+	It("should record the upgrade with the previous version", func() {
+		startUpgrade(provider, "v2.9.0")
+		Expect(provider.Status.Upgrade).ToNot(BeNil())
+		Expect(provider.Status.Upgrade.PreviousVersion).To(Equal("v2.8.0"))
+		Expect(provider.Status.Upgrade.Version).To(Equal("v2.9.0"))
+
+		provider.Spec.Version = "v2.9.0"
+		startUpgrade(provider, "v2.10.0")
+		Expect(provider.Status.Upgrade.PreviousVersion).To(Equal("v2.8.0"))
+		Expect(provider.Status.Upgrade.Version).To(Equal("v2.10.0"))
+	})
+
+	It("should not record fresh installations or upgrades without a strategy", func() {
+		provider.Spec.Version = ""
+		startUpgrade(provider, "v2.9.0")
+		Expect(provider.Status.Upgrade).To(BeNil())
+
+		provider.Spec.Version = "v2.8.0"
+		provider.Spec.UpgradeStrategy = nil
+		startUpgrade(provider, "v2.9.0")
+		Expect(provider.Status.Upgrade).To(BeNil())
+	})
+
+	Context("with an upgrade in progress", func() {
+		BeforeEach(func() {
+			startUpgrade(provider, "v2.9.0")
+			provider.Spec.Version = "v2.9.0"
+		})
+
+		It("should complete the upgrade once the provider is healthy", func() {
+			provider.Status.InstalledVersion = ptr.To("v2.9.0")
+
+			res, err := CheckUpgradeHealth(ctx, fakeClient, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeZero())
+			Expect(provider.Status.Upgrade).To(BeNil())
+			Expect(provider.Spec.Version).To(Equal("v2.9.0"))
+		})
+
+		Context("when the provider Deployment is not Available", func() {
+			BeforeEach(func() {
+				deployment.Status.Conditions[0].Status = corev1.ConditionFalse
+			})
+
+			It("should wait for the provider within the timeout", func() {
+				provider.Status.InstalledVersion = ptr.To("v2.9.0")
+
+				res, err := CheckUpgradeHealth(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.RequeueAfter).To(Equal(upgradeHealthCheckInterval))
+				Expect(provider.Status.Upgrade).ToNot(BeNil())
+			})
+
+			It("should roll back to the previous version after the timeout", func() {
+				provider.Status.InstalledVersion = ptr.To("v2.9.0")
+				provider.Status.Upgrade.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+				_, err := CheckUpgradeHealth(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(provider.Spec.Version).To(Equal("v2.8.0"))
+				Expect(provider.Status.FailedVersion).To(Equal("v2.9.0"))
+				Expect(provider.Status.Upgrade).To(BeNil())
+
+				condition := conditions.Get(provider, turtlesv1.ProviderUpgradeRolledBackCondition)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(turtlesv1.ProviderUpgradeTimedOutReason))
+			})
+		})
+
+		Context("when the provider CRDs are not established", func() {
+			BeforeEach(func() {
+				crd.Status.Conditions[0].Status = apiextensionsv1.ConditionFalse
+			})
+
+			It("should roll back to the previous version after the timeout", func() {
+				provider.Status.InstalledVersion = ptr.To("v2.9.0")
+				provider.Status.Upgrade.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+				_, err := CheckUpgradeHealth(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(provider.Spec.Version).To(Equal("v2.8.0"))
+				Expect(conditions.IsTrue(provider, turtlesv1.ProviderUpgradeRolledBackCondition)).To(BeTrue())
+			})
+		})
+
+		Context("when the new version fails to be fetched or installed", func() {
+			BeforeEach(func() {
+				provider.Status.InstalledVersion = ptr.To("v2.8.0")
+			})
+
+			It("should not roll back before the timeout", func() {
+				res, err := RollbackTimedOutUpgrade(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.IsZero()).To(BeTrue())
+				Expect(provider.Spec.Version).To(Equal("v2.9.0"))
+				Expect(provider.Status.Upgrade).ToNot(BeNil())
+			})
+
+			It("should roll back before the install phases after the timeout", func() {
+				provider.Status.Upgrade.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+				res, err := RollbackTimedOutUpgrade(ctx, fakeClient, provider)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Requeue).To(BeTrue())
+				Expect(provider.Spec.Version).To(Equal("v2.8.0"))
+				Expect(provider.Status.FailedVersion).To(Equal("v2.9.0"))
+				Expect(provider.Status.Upgrade).To(BeNil())
+				Expect(conditions.IsTrue(provider, turtlesv1.ProviderUpgradeRolledBackCondition)).To(BeTrue())
+			})
+		})
+
+		It("should let a healthy provider continue after the timeout", func() {
+			provider.Status.InstalledVersion = ptr.To("v2.9.0")
+			provider.Status.Upgrade.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+			res, err := RollbackTimedOutUpgrade(ctx, fakeClient, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+			Expect(provider.Spec.Version).To(Equal("v2.9.0"))
+			Expect(provider.Status.Upgrade).To(BeNil())
+		})
+
+		It("should roll back when the new version is not installed in time", func() {
+			provider.Status.Upgrade.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+			_, err := CheckUpgradeHealth(ctx, fakeClient, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(provider.Spec.Version).To(Equal("v2.8.0"))
+			Expect(provider.Status.FailedVersion).To(Equal("v2.9.0"))
+		})
+	})
+})
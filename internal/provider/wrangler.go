@@ -465,6 +465,19 @@ func providerDeploymentRestart(ctx context.Context, cl client.Client, provider *
 }
 
 func getSelector(provider *turtlesv1.CAPIProvider) ([]client.ListOption, error) {
+	selector, err := getLabelSelector(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return []client.ListOption{
+		client.InNamespace(provider.GetNamespace()),
+		selector,
+	}, nil
+}
+
+// getLabelSelector returns the selector of the resources applied for a provider, including cluster-scoped ones.
+func getLabelSelector(provider *turtlesv1.CAPIProvider) (client.MatchingLabelsSelector, error) {
 	var matchingLabels []string
 	if provider.Spec.Name != "" {
 		matchingLabels = []string{
@@ -480,16 +493,11 @@ func getSelector(provider *turtlesv1.CAPIProvider) ([]client.ListOption, error)
 
 	requirement, err := labels.NewRequirement(CAPIProviderLabel, selection.In, matchingLabels)
 	if err != nil {
-		return nil, fmt.Errorf("creating labels requirement: %w", err)
+		return client.MatchingLabelsSelector{}, fmt.Errorf("creating labels requirement: %w", err)
 	}
 
-	selector := client.MatchingLabelsSelector{
+	return client.MatchingLabelsSelector{
 		Selector: labels.NewSelector().
 			Add(*requirement),
-	}
-
-	return []client.ListOption{
-		client.InNamespace(provider.GetNamespace()),
-		selector,
 	}, nil
 }
@@ -21,13 +21,16 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/component-base/version"
 	"k8s.io/klog/v2"
@@ -35,12 +38,14 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	operatorv1 "sigs.k8s.io/cluster-api-operator/api/v1alpha2"
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	managementv3 "github.com/rancher/turtles/api/rancher/management/v3"
@@ -67,6 +72,20 @@ var (
 	concurrencyNumber           int
 	managerConcurrency          int
 	insecureSkipVerify          bool
+	rancherKubeconfig           string
+	propagateLabels             []string
+	propagateAnnotations        []string
+	importServerSideApply       bool
+	agentDisconnectThreshold    time.Duration
+	agentCleanup                bool
+	manifestDownloadTimeout     time.Duration
+	manifestDownloadRetries     int
+	manifestDownloadInterval    time.Duration
+	manifestMaxSize             int64
+	manifestDownloadProxy       string
+	manifestCABundleSecret      string
+	manifestSource              string
+	rancherServiceURL           string
 )
 
 func init() {
@@ -74,7 +93,9 @@ func init() {
 
 	//+kubebuilder:scaffold:scheme
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(addonsv1.AddToScheme(scheme))
 	utilruntime.Must(provisioningv1.AddToScheme(scheme))
 	utilruntime.Must(managementv3.AddToScheme(scheme))
 	utilruntime.Must(operatorv1.AddToScheme(scheme))
@@ -119,6 +140,48 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&insecureSkipVerify, "insecure-skip-verify", false,
 		"Skip TLS certificate verification when connecting to Rancher. Only used for development and testing purposes. Use at your own risk.")
 
+	fs.StringVar(&rancherKubeconfig, "rancher-kubeconfig", "",
+		"Path to the kubeconfig file of the Rancher Manager cluster. If unspecified, Rancher is expected to run in the same cluster as Turtles.")
+
+	fs.StringSliceVar(&propagateLabels, "propagate-labels", nil,
+		"Comma-separated list of CAPI Cluster label keys to sync to the imported Rancher cluster. Keys ending with '/' match all labels with that prefix.")
+
+	fs.StringSliceVar(&propagateAnnotations, "propagate-annotations", nil,
+		"Comma-separated list of CAPI Cluster annotation keys to sync to the imported Rancher cluster. Keys ending with '/' match all annotations with that prefix.")
+
+	fs.BoolVar(&importServerSideApply, "import-server-side-apply", false,
+		"Server-side apply the Rancher import manifest, repairing drift of the agent objects on the downstream cluster. Can be overridden per cluster with the cluster-api.cattle.io/import-server-side-apply annotation.") //nolint:lll
+
+	fs.DurationVar(&agentDisconnectThreshold, "agent-disconnect-threshold", 5*time.Minute,
+		"Time a previously connected Rancher agent can be disconnected before the import manifest is applied again (e.g. 10m)")
+
+	fs.BoolVar(&agentCleanup, "agent-cleanup", false,
+		"Remove the Rancher agent from the downstream cluster before releasing a deleted Rancher cluster with the Cascade deletion policy.")
+
+	fs.DurationVar(&manifestDownloadTimeout, "manifest-download-timeout", 30*time.Second,
+		"Timeout of a single Rancher registration manifest download attempt (e.g. 1m)")
+
+	fs.IntVar(&manifestDownloadRetries, "manifest-download-retries", 3,
+		"Number of times a failed Rancher registration manifest download is retried, one attempt per reconcile")
+
+	fs.DurationVar(&manifestDownloadInterval, "manifest-download-retry-interval", time.Second,
+		"Wait before the first retry of a failed registration manifest download, doubled after every attempt (e.g. 2s)")
+
+	fs.Int64Var(&manifestMaxSize, "manifest-max-size", 10<<20,
+		"Maximum size of a Rancher registration manifest in bytes")
+
+	fs.StringVar(&manifestDownloadProxy, "manifest-download-proxy", "",
+		"URL of the proxy used to download the Rancher registration manifests. If unspecified, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.") //nolint:lll
+
+	fs.StringVar(&manifestCABundleSecret, "manifest-ca-bundle-secret", "",
+		"Secret holding additional CA certificates in its ca.crt key, trusted when downloading the Rancher registration manifests (namespace/name)")
+
+	fs.StringVar(&manifestSource, "manifest-source", string(controllers.ManifestSourceExternal),
+		"Where the Rancher registration manifests are downloaded from: external (the Rancher server-url), service (the in-cluster Rancher Service with the path of the server-url manifest) or token (the in-cluster Rancher Service with the path built from the registration token)") //nolint:lll
+
+	fs.StringVar(&rancherServiceURL, "rancher-service-url", "https://rancher.cattle-system.svc",
+		"URL of the in-cluster Rancher Service, used with the service and token manifest sources")
+
 	feature.MutableGates.AddFlag(fs)
 }
 
@@ -170,7 +233,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
-	setupReconcilers(ctx, mgr)
+	setupReconcilers(ctx, mgr, setupRancherCluster(mgr))
 
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager", "version", version.Get().String())
@@ -193,7 +256,45 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
-func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
+// setupRancherCluster returns the cluster used to access Rancher Manager resources.
+// When no kubeconfig is provided, Rancher is running in the same cluster and the manager is used directly.
+func setupRancherCluster(mgr ctrl.Manager) cluster.Cluster {
+	if rancherKubeconfig == "" {
+		return mgr
+	}
+
+	setupLog.Info("using external Rancher Manager cluster", "kubeconfig", rancherKubeconfig)
+
+	rancherConfig, err := clientcmd.BuildConfigFromFlags("", rancherKubeconfig)
+	if err != nil {
+		setupLog.Error(err, "unable to load Rancher kubeconfig")
+		os.Exit(1)
+	}
+
+	rancherCluster, err := cluster.New(rancherConfig, func(o *cluster.Options) {
+		o.Scheme = scheme
+		o.Cache.SyncPeriod = &syncPeriod
+		o.Client.Cache = &client.CacheOptions{
+			DisableFor: []client.Object{
+				&corev1.ConfigMap{},
+				&corev1.Secret{},
+			},
+		}
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create Rancher cluster client")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(rancherCluster); err != nil {
+		setupLog.Error(err, "unable to add Rancher cluster to manager")
+		os.Exit(1)
+	}
+
+	return rancherCluster
+}
+
+func setupReconcilers(ctx context.Context, mgr ctrl.Manager, rancherCluster cluster.Cluster) {
 	uncachedClientOptions := client.Options{
 		Scheme: mgr.GetClient().Scheme(),
 		Cache: &client.CacheOptions{
@@ -209,12 +310,47 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	manifestDownloadOptions := controllers.ManifestDownloadOptions{
+		Timeout:           manifestDownloadTimeout,
+		Retries:           manifestDownloadRetries,
+		RetryInterval:     manifestDownloadInterval,
+		MaxSize:           manifestMaxSize,
+		ProxyURL:          manifestDownloadProxy,
+		Source:            controllers.ManifestSource(manifestSource),
+		RancherServiceURL: rancherServiceURL,
+	}
+
+	switch manifestDownloadOptions.Source {
+	case controllers.ManifestSourceExternal, controllers.ManifestSourceService, controllers.ManifestSourceToken:
+	default:
+		setupLog.Error(nil, "invalid manifest-source, expected one of external, service, token", "value", manifestSource)
+		os.Exit(1)
+	}
+
+	if manifestCABundleSecret != "" {
+		namespace, name, found := strings.Cut(manifestCABundleSecret, "/")
+		if !found || namespace == "" || name == "" {
+			setupLog.Error(nil, "invalid manifest-ca-bundle-secret, expected namespace/name", "value", manifestCABundleSecret)
+			os.Exit(1)
+		}
+
+		manifestDownloadOptions.CABundleSecret = client.ObjectKey{Namespace: namespace, Name: name}
+	}
+
 	if err := (&controllers.CAPIImportReconciler{
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
-		UncachedClient:     uncachedClient,
-		WatchFilterValue:   watchFilterValue,
-		InsecureSkipVerify: insecureSkipVerify,
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		UncachedClient:           uncachedClient,
+		RancherClient:            rancherCluster.GetClient(),
+		RancherCache:             rancherCluster.GetCache(),
+		WatchFilterValue:         watchFilterValue,
+		InsecureSkipVerify:       insecureSkipVerify,
+		PropagateLabels:          propagateLabels,
+		PropagateAnnotations:     propagateAnnotations,
+		ServerSideApply:          importServerSideApply,
+		AgentDisconnectThreshold: agentDisconnectThreshold,
+		AgentCleanup:             agentCleanup,
+		ManifestDownload:         manifestDownloadOptions,
 	}).SetupWithManager(ctx, mgr, controller.Options{
 		MaxConcurrentReconciles: concurrencyNumber,
 	}); err != nil {
@@ -223,7 +359,9 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	}
 
 	if err := (&controllers.CAPICleanupReconciler{
-		Client: mgr.GetClient(),
+		Client:        mgr.GetClient(),
+		RancherClient: rancherCluster.GetClient(),
+		RancherCache:  rancherCluster.GetCache(),
 	}).SetupWithManager(ctx, mgr, controller.Options{
 		MaxConcurrentReconciles: concurrencyNumber,
 	}); err != nil {
@@ -234,7 +372,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	setupLog.Info("enabling Clusterctl Config synchronization controller")
 
 	if err := (&controllers.ClusterctlConfigReconciler{
-		Client: mgr.GetClient(),
+		Client:        mgr.GetClient(),
+		RancherClient: rancherCluster.GetClient(),
 	}).SetupWithManager(ctx, mgr, controller.Options{
 		MaxConcurrentReconciles: concurrencyNumber,
 	}); err != nil {
@@ -244,7 +383,9 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	setupLog.Info("enabling CAPI Operator synchronization controller")
 
-	if err := (&controllers.OperatorReconciler{}).SetupWithManager(ctx, mgr, controller.Options{
+	if err := (&controllers.OperatorReconciler{
+		RancherClient: rancherCluster.GetClient(),
+	}).SetupWithManager(ctx, mgr, controller.Options{
 		MaxConcurrentReconciles: concurrencyNumber,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Operator")
@@ -18,6 +18,9 @@ package annotations
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
 )
 
 const (
@@ -39,6 +42,29 @@ const (
 	LocalSystemAgentAnnotation = "cluster-api.cattle.io/local-system-agent"
 	// ClusterDescriptionAnnotation is a cluster annotation, allowing user to provide a custom cluster description.
 	ClusterDescriptionAnnotation = "cluster-api.cattle.io/cluster-description"
+	// ImportServerSideApplyAnnotation is a cluster annotation, enabling or disabling server-side apply of the Rancher import manifest
+	// for the cluster. It takes precedence over the controller-wide setting.
+	ImportServerSideApplyAnnotation = "cluster-api.cattle.io/import-server-side-apply"
+	// ReimportAnnotation is a cluster annotation, requesting a re-import of the cluster into Rancher every time its value changes,
+	// e.g. when set to the current timestamp.
+	ReimportAnnotation = "cluster-api.cattle.io/reimport"
+	// ReimportHandledAnnotation is a cluster annotation, holding the value of the last handled ReimportAnnotation.
+	ReimportHandledAnnotation = "cluster-api.cattle.io/reimport-handled"
+	// ClusterRoleBindingsAnnotation is a cluster or namespace annotation, listing Rancher role templates to grant on the imported cluster
+	// as comma-separated `<roleTemplate>=<kind>:<subject>` entries, where kind is one of user, userPrincipal or group.
+	ClusterRoleBindingsAnnotation = "cluster-api.cattle.io/cluster-role-bindings"
+	// ProjectsAnnotation is a cluster or namespace annotation, listing comma-separated display names of Rancher projects
+	// to create in the imported cluster. Projects removed from the list are kept in Rancher, and no longer managed by Turtles.
+	ProjectsAnnotation = "cluster-api.cattle.io/projects"
+	// DeletionPolicyAnnotation is a cluster annotation, selecting the deletion policy of the imported cluster: Cascade, Orphan or Detach.
+	// It takes precedence over the cluster import policy.
+	DeletionPolicyAnnotation = "cluster-api.cattle.io/deletion-policy"
+	// ImportModeAnnotation is a cluster annotation, selecting how the Rancher import manifest is delivered to the cluster: Push or Pull.
+	// It takes precedence over the import policy agent mode.
+	ImportModeAnnotation = "cluster-api.cattle.io/import-mode"
+	// PausedAnnotation is a cluster annotation, pausing the import of the cluster and the changes to its Rancher cluster
+	// without pausing the CAPI controllers.
+	PausedAnnotation = "turtles.cattle.io/paused"
 	// ImportedClusterVersionManagementAnnotation is a Rancher management Cluster annotation that enables or disables version management for the Cluster.
 	ImportedClusterVersionManagementAnnotation = "rancher.io/imported-cluster-version-management"
 )
@@ -48,6 +74,19 @@ func HasClusterImportAnnotation(o metav1.Object) bool {
 	return HasAnnotation(o, ClusterImportedAnnotation)
 }
 
+// HasPendingReimport returns true if the object has a `reimport` annotation which was not handled yet.
+func HasPendingReimport(o metav1.Object) bool {
+	annotations := o.GetAnnotations()
+	requested := annotations[ReimportAnnotation]
+
+	return requested != "" && requested != annotations[ReimportHandledAnnotation]
+}
+
+// IsPaused returns true if the CAPI cluster is paused with spec.paused, the CAPI paused annotation or the Turtles paused annotation.
+func IsPaused(cluster *clusterv1.Cluster) bool {
+	return capiannotations.IsPaused(cluster, cluster) || HasAnnotation(cluster, PausedAnnotation)
+}
+
 // HasAnnotation returns true if the object has the specified annotation.
 func HasAnnotation(o metav1.Object, annotation string) bool {
 	annotations := o.GetAnnotations()
@@ -71,6 +71,37 @@ var _ = Describe("ClusterWithoutImportedAnnotation", func() {
 	})
 })
 
+var _ = Describe("HasPendingReimport", func() {
+	It("should return false when no re-import is requested", func() {
+		obj := &clusterv1.Cluster{}
+		Expect(HasPendingReimport(obj)).To(BeFalse())
+	})
+
+	It("should return true when the requested re-import was not handled", func() {
+		obj := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					ReimportAnnotation:        "2025-01-02T00:00:00Z",
+					ReimportHandledAnnotation: "2025-01-01T00:00:00Z",
+				},
+			},
+		}
+		Expect(HasPendingReimport(obj)).To(BeTrue())
+	})
+
+	It("should return false when the requested re-import was handled", func() {
+		obj := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					ReimportAnnotation:        "2025-01-01T00:00:00Z",
+					ReimportHandledAnnotation: "2025-01-01T00:00:00Z",
+				},
+			},
+		}
+		Expect(HasPendingReimport(obj)).To(BeFalse())
+	})
+})
+
 func TestAnnotationHelpers(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "AnnotationHelpers Suite")
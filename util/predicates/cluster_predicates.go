@@ -33,7 +33,8 @@ import (
 )
 
 // ClusterWithoutImportedAnnotation returns a predicate that returns true only if the provided resource does not contain
-// "clusterImportedAnnotation" annotation. When annotation is present on the resource, controller will skip reconciliation.
+// "clusterImportedAnnotation" annotation. When annotation is present on the resource, controller will skip reconciliation,
+// unless a re-import of the cluster is requested.
 func ClusterWithoutImportedAnnotation(logger logr.Logger) predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -56,6 +57,11 @@ func processIfClusterNotImported(logger logr.Logger, obj client.Object) bool {
 	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
 	log := logger.WithValues("namespace", obj.GetNamespace(), kind, obj.GetName())
 
+	if annotations.HasPendingReimport(obj) {
+		log.V(6).Info("Cluster has a pending re-import request, will attempt to map resource")
+		return true
+	}
+
 	if annotations.HasAnnotation(obj, annotations.ClusterImportedAnnotation) {
 		log.V(4).Info("Cluster has an import annotation, will not attempt to map resource")
 		return false
@@ -106,6 +112,46 @@ func processIfClusterReadyControlPlane(logger logr.Logger, obj client.Object) bo
 	return false
 }
 
+// ClusterNotPaused returns a predicate that returns true only if the provided resource is a cluster which is not paused
+// with spec.paused, the CAPI paused annotation or the Turtles paused annotation.
+func ClusterNotPaused(logger logr.Logger) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return processIfClusterNotPaused(logger.WithValues("predicate", "ClusterNotPaused", "eventType", "update"), e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return processIfClusterNotPaused(logger.WithValues("predicate", "ClusterNotPaused", "eventType", "create"), e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return processIfClusterNotPaused(logger.WithValues("predicate", "ClusterNotPaused", "eventType", "delete"), e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return processIfClusterNotPaused(logger.WithValues("predicate", "ClusterNotPaused", "eventType", "generic"), e.Object)
+		},
+	}
+}
+
+// processIfClusterNotPaused returns true if the provided object is a cluster which is not paused.
+func processIfClusterNotPaused(logger logr.Logger, obj client.Object) bool {
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	log := logger.WithValues("namespace", obj.GetNamespace(), kind, obj.GetName())
+
+	cluster, ok := obj.(*clusterv1.Cluster)
+	if !ok {
+		log.V(4).Info("Expected a Cluster but got a different object, will not attempt to map resource", "object", obj)
+		return false
+	}
+
+	if annotations.IsPaused(cluster) {
+		log.V(4).Info("Cluster is paused, will not attempt to map resource")
+		return false
+	}
+
+	log.V(6).Info("Cluster is not paused, will attempt to map resource")
+
+	return true
+}
+
 // ClusterOrNamespaceWithImportLabel returns a predicate that returns true only if the provided resource is a cluster and
 // has an import label set on it or on its namespace.
 func ClusterOrNamespaceWithImportLabel(ctx context.Context, logger logr.Logger, cl client.Client, label string) predicate.Funcs {
@@ -20,9 +20,11 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
 	"github.com/rancher/turtles/util/annotations"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -55,6 +57,16 @@ var _ = Describe("ClusterWithoutImportedAnnotation", func() {
 			Expect(result).To(BeFalse())
 		})
 	})
+	Context("when CAPI cluster has clusterImportedAnnotation and a pending re-import", func() {
+		It("should return true", func() {
+			capiCluster.Annotations = map[string]string{
+				annotations.ClusterImportedAnnotation: "true",
+				annotations.ReimportAnnotation:        "2025-01-01T00:00:00Z",
+			}
+			result := ClusterWithoutImportedAnnotation(logger).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
+			Expect(result).To(BeTrue())
+		})
+	})
 	Context("when CAPI cluster has no annotation", func() {
 		It("should return true", func() {
 			result := ClusterWithoutImportedAnnotation(logger).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
@@ -106,6 +118,38 @@ var _ = Describe("ClusterWithReadyControlPlane", func() {
 	})
 })
 
+var _ = Describe("ClusterNotPaused", func() {
+	var capiCluster *clusterv1.Cluster
+
+	BeforeEach(func() {
+		capiCluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-ns",
+			},
+		}
+	})
+
+	It("should return true when cluster is not paused", func() {
+		Expect(ClusterNotPaused(logr.Discard()).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})).To(BeTrue())
+	})
+
+	It("should return false when cluster is paused with spec.paused", func() {
+		capiCluster.Spec.Paused = ptr.To(true)
+		Expect(ClusterNotPaused(logr.Discard()).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})).To(BeFalse())
+	})
+
+	It("should return false when cluster has the CAPI paused annotation", func() {
+		capiCluster.Annotations = map[string]string{clusterv1.PausedAnnotation: ""}
+		Expect(ClusterNotPaused(logr.Discard()).CreateFunc(event.CreateEvent{Object: capiCluster})).To(BeFalse())
+	})
+
+	It("should return false when cluster has the Turtles paused annotation", func() {
+		capiCluster.Annotations = map[string]string{annotations.PausedAnnotation: "true"}
+		Expect(ClusterNotPaused(logr.Discard()).CreateFunc(event.CreateEvent{Object: capiCluster})).To(BeFalse())
+	})
+})
+
 var _ = Describe("ClusterOrNamespaceWithImportLabel", func() {
 	var (
 		logger      logr.Logger
@@ -166,4 +210,46 @@ var _ = Describe("ClusterOrNamespaceWithImportLabel", func() {
 		result := ClusterOrNamespaceWithImportLabel(ctx, logger, cl, importLabel).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
 		Expect(result).To(BeFalse())
 	})
+
+	It("should return true when an import policy selects the cluster", func() {
+		namespace.Name = "test-ns-3"
+		namespace.Labels = nil
+		Expect(cl.Create(ctx, namespace)).To(Succeed())
+		Expect(cl.Create(ctx, &turtlesv1.ClusterImportPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: namespace.Name},
+			Spec: turtlesv1.ClusterImportPolicySpec{
+				ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+		})).To(Succeed())
+
+		capiCluster.Namespace = namespace.Name
+		capiCluster.Labels = map[string]string{"env": "prod"}
+
+		Eventually(func() bool {
+			return ClusterOrNamespaceWithImportLabel(ctx, logger, cl, importLabel).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
+		}).Should(BeTrue())
+
+		capiCluster.Labels = map[string]string{"env": "dev"}
+		result := ClusterOrNamespaceWithImportLabel(ctx, logger, cl, importLabel).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
+		Expect(result).To(BeFalse())
+
+		capiCluster.Labels = map[string]string{"env": "prod", importLabel: "false"}
+		result = ClusterOrNamespaceWithImportLabel(ctx, logger, cl, importLabel).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
+		Expect(result).To(BeFalse())
+	})
+
+	It("should let the namespace import label override import policies", func() {
+		namespace.Name = "test-ns-4"
+		namespace.Labels = map[string]string{importLabel: "false"}
+		Expect(cl.Create(ctx, namespace)).To(Succeed())
+		Expect(cl.Create(ctx, &turtlesv1.ClusterImportPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: namespace.Name},
+		})).To(Succeed())
+
+		capiCluster.Namespace = namespace.Name
+
+		Consistently(func() bool {
+			return ClusterOrNamespaceWithImportLabel(ctx, logger, cl, importLabel).UpdateFunc(event.UpdateEvent{ObjectNew: capiCluster})
+		}).Should(BeFalse())
+	})
 })
@@ -67,6 +67,7 @@ var _ = BeforeSuite(func() {
 
 	testEnvConfig := helpers.NewTestEnvironmentConfiguration(
 		path.Join("hack", "crd", "bases"),
+		path.Join("config", "crd", "bases"),
 	)
 
 	testEnv, err = testEnvConfig.Build()
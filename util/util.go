@@ -18,15 +18,20 @@ package util
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
+	turtlesv1 "github.com/rancher/turtles/api/v1alpha1"
 	turtlesannotations "github.com/rancher/turtles/util/annotations"
 )
 
@@ -45,6 +50,37 @@ func ShouldImport(obj metav1.Object, label string) (hasLabel bool, labelValue bo
 	return true, autoImport
 }
 
+// FleetWorkspace returns the Fleet workspace selected with the label on the CAPI cluster or,
+// when the cluster has no such label, on its namespace. An empty string is returned when no workspace is selected.
+func FleetWorkspace(ctx context.Context, cl client.Client, capiCluster *clusterv1.Cluster, label string) (string, error) {
+	if workspace := capiCluster.GetLabels()[label]; workspace != "" {
+		return workspace, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: capiCluster.Namespace}, ns); err != nil {
+		return "", fmt.Errorf("getting namespace %s: %w", capiCluster.Namespace, err)
+	}
+
+	return ns.GetLabels()[label], nil
+}
+
+// ClusterOrNamespaceAnnotation returns the value of the annotation on the CAPI cluster or, when the cluster
+// does not have the annotation, on its namespace. Setting the annotation to an empty value on the cluster
+// overrides the namespace value.
+func ClusterOrNamespaceAnnotation(ctx context.Context, cl client.Client, capiCluster *clusterv1.Cluster, annotation string) (string, error) {
+	if value, found := capiCluster.GetAnnotations()[annotation]; found {
+		return value, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: capiCluster.Namespace}, ns); err != nil {
+		return "", fmt.Errorf("getting namespace %s: %w", capiCluster.Namespace, err)
+	}
+
+	return ns.GetAnnotations()[annotation], nil
+}
+
 // ShouldAutoImport checks if the namespace or cluster has the label set to true.
 func ShouldAutoImport(ctx context.Context, logger logr.Logger, cl client.Client, capiCluster *clusterv1.Cluster, label string) (bool, error) {
 	logger.V(2).Info("should we auto import the capi cluster", "name", capiCluster.Name, "namespace", capiCluster.Namespace)
@@ -72,7 +108,48 @@ func ShouldAutoImport(ctx context.Context, logger logr.Logger, cl client.Client,
 		return false, err
 	}
 
-	_, autoImport = ShouldImport(ns, label)
+	if hasLabel, autoImport = ShouldImport(ns, label); hasLabel {
+		return autoImport, nil
+	}
+
+	// Check import policies in the namespace
+	policy, err := ClusterImportPolicy(ctx, cl, capiCluster)
+	if err != nil {
+		logger.Error(err, "getting cluster import policy")
+		return false, err
+	}
+
+	if policy != nil {
+		logger.V(2).Info("Cluster is selected by an import policy", "policy", policy.Name)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ClusterImportPolicy returns the first ClusterImportPolicy by name in the CAPI cluster namespace
+// selecting the cluster, or nil when no policy selects it.
+func ClusterImportPolicy(ctx context.Context, cl client.Client, capiCluster *clusterv1.Cluster) (*turtlesv1.ClusterImportPolicy, error) {
+	policies := &turtlesv1.ClusterImportPolicyList{}
+	if err := cl.List(ctx, policies, client.InNamespace(capiCluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing cluster import policies: %w", err)
+	}
+
+	slices.SortFunc(policies.Items, func(a, b turtlesv1.ClusterImportPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for i, policy := range policies.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.ClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector in cluster import policy %s: %w", policy.Name, err)
+		}
+
+		if selector.Matches(labels.Set(capiCluster.GetLabels())) {
+			return &policies.Items[i], nil
+		}
+	}
 
-	return autoImport, nil
+	return nil, nil //nolint:nilnil // no policy selects the cluster
 }